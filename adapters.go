@@ -0,0 +1,65 @@
+package libhealth
+
+import (
+	"context"
+	"errors"
+)
+
+// DegradedError is an error which carries the Status that a HealthChecker
+// created by FromError reports for it. Create one with Degraded.
+type DegradedError struct {
+	Status Status
+	Err    error
+}
+
+// Degraded wraps err so that a HealthChecker created by FromError reports
+// status rather than OUTAGE for it, or for any error which wraps it. This
+// lets code deep within a check signal that something is degraded but not
+// down. If err is nil, Degraded returns nil.
+func Degraded(err error, status Status) error {
+	if err == nil {
+		return nil
+	}
+	return &DegradedError{Status: status, Err: err}
+}
+
+func (e *DegradedError) Error() string {
+	return e.Err.Error()
+}
+
+// Unwrap returns the wrapped error.
+func (e *DegradedError) Unwrap() error {
+	return e.Err
+}
+
+// FromError creates a HealthChecker from a check which returns an error.
+// When the check returns nil the Health is OK. Otherwise its message is that
+// of the error, and its Status is OUTAGE unless the error is or wraps one
+// created by Degraded.
+func FromError(check func(ctx context.Context) error) HealthChecker {
+	return func(ctx context.Context) Health {
+		err := check(ctx)
+		if err == nil {
+			return NewHealth(OK, "ok")
+		}
+
+		status := OUTAGE
+		var degraded *DegradedError
+		if errors.As(err, &degraded) {
+			status = degraded.Status
+		}
+		return NewHealth(status, err.Error()).WithError(err)
+	}
+}
+
+// FromBool creates a HealthChecker from a check which returns whether
+// everything is OK. When the check returns false the Health is an OUTAGE
+// with message.
+func FromBool(check func(ctx context.Context) bool, message string) HealthChecker {
+	return func(ctx context.Context) Health {
+		if check(ctx) {
+			return NewHealth(OK, "ok")
+		}
+		return NewHealth(OUTAGE, message)
+	}
+}
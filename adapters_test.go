@@ -0,0 +1,57 @@
+package libhealth
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestFromError(t *testing.T) {
+	refused := errors.New("connection refused")
+	slow := Degraded(errors.New("replica is slow"), MINOR)
+
+	tests := []struct {
+		err     error
+		status  Status
+		message Message
+	}{
+		{err: nil, status: OK, message: "ok"},
+		{err: refused, status: OUTAGE, message: "connection refused"},
+		{err: slow, status: MINOR, message: "replica is slow"},
+		{err: fmt.Errorf("querying: %w", slow), status: MINOR, message: "querying: replica is slow"},
+	}
+
+	for _, test := range tests {
+		err := test.err
+		h := FromError(func(ctx context.Context) error {
+			return err
+		})(context.Background())
+
+		require.Equal(t, test.status, h.Status)
+		require.Equal(t, test.message, h.Message)
+		require.Equal(t, test.err, h.Err)
+	}
+}
+
+func TestDegraded(t *testing.T) {
+	require.Nil(t, Degraded(nil, MAJOR))
+
+	err := errors.New("replica is slow")
+	require.True(t, errors.Is(Degraded(err, MAJOR), err))
+}
+
+func TestFromBool(t *testing.T) {
+	ok := FromBool(func(ctx context.Context) bool {
+		return true
+	}, "not ok")(context.Background())
+	require.Equal(t, OK, ok.Status)
+
+	notOk := FromBool(func(ctx context.Context) bool {
+		return false
+	}, "not ok")(context.Background())
+	require.Equal(t, OUTAGE, notOk.Status)
+	require.Equal(t, Message("not ok"), notOk.Message)
+}
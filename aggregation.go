@@ -0,0 +1,72 @@
+package libhealth
+
+// An AggregationPolicy computes the overall Status of a Summary from its
+// Results. The Status of each Result has already been downgraded by its
+// Urgency, and Results which are blocked by a failing parent or pending
+// are not included. A BasicDependencySet only looks for an OverallTransition
+// once the Status of one of its Results changes, or its registrations or
+// Overrides change.
+type AggregationPolicy interface {
+	Aggregate(results []Result) Status
+}
+
+// AggregationFunc adapts a function to an AggregationPolicy.
+type AggregationFunc func(results []Result) Status
+
+// Aggregate calls f(results).
+func (f AggregationFunc) Aggregate(results []Result) Status {
+	return f(results)
+}
+
+// HCv3 is the default AggregationPolicy. The overall Status is the worst
+// Status of the Results, each downgraded by its Urgency according to HCv3
+// math; see Urgency.DowngradeWith.
+var HCv3 AggregationPolicy = AggregationFunc(hcv3)
+
+func hcv3(results []Result) Status {
+	lowest := OK
+	for _, r := range results {
+		downgraded := r.Urgency.DowngradeWith(lowest, r.Status)
+		if downgraded.SameOrWorseThan(lowest) {
+			lowest = downgraded
+		}
+	}
+	return lowest
+}
+
+// Cap wraps p so that the Status of Results with urgency is no worse than
+// status, such as to cap STRONG dependencies at MINOR.
+func Cap(p AggregationPolicy, urgency Urgency, status Status) AggregationPolicy {
+	return AggregationFunc(func(results []Result) Status {
+		capped := make([]Result, len(results))
+		for i, r := range results {
+			capped[i] = r
+			if r.Urgency == urgency {
+				capped[i].Status = BestState(r.Status, status)
+			}
+		}
+		return p.Aggregate(capped)
+	})
+}
+
+// Escalate wraps p so that the overall Status is at least as bad as status
+// while failures or more of the Results with urgency are not OK, such as to
+// escalate two failing WEAK dependencies to MAJOR. A failures of less than 1
+// is treated as 1.
+func Escalate(p AggregationPolicy, urgency Urgency, failures int, status Status) AggregationPolicy {
+	failures = atLeastOne(failures)
+	return AggregationFunc(func(results []Result) Status {
+		overall := p.Aggregate(results)
+
+		failing := 0
+		for _, r := range results {
+			if r.Urgency == urgency && !r.SameAs(OK) {
+				failing++
+			}
+		}
+		if failing >= failures {
+			return WorstState(overall, status)
+		}
+		return overall
+	})
+}
@@ -0,0 +1,90 @@
+package libhealth
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func result(name string, urgency Urgency, status Status) Result {
+	h := NewHealth(status, status.String())
+	h.Urgency = urgency
+	return Result{Health: h, name: name}
+}
+
+func TestHCv3(t *testing.T) {
+	require.Equal(t, OK, HCv3.Aggregate(nil))
+	require.Equal(t, MINOR, HCv3.Aggregate([]Result{
+		result("a", WEAK, MINOR),
+		result("b", REQUIRED, OK),
+	}))
+	require.Equal(t, OUTAGE, HCv3.Aggregate([]Result{
+		result("a", STRONG, MAJOR),
+		result("b", REQUIRED, OUTAGE),
+	}))
+}
+
+func TestCap(t *testing.T) {
+	policy := Cap(HCv3, STRONG, MINOR)
+	require.Equal(t, MINOR, policy.Aggregate([]Result{
+		result("a", STRONG, MAJOR),
+		result("b", WEAK, MINOR),
+	}))
+	require.Equal(t, OUTAGE, policy.Aggregate([]Result{
+		result("a", STRONG, MAJOR),
+		result("b", REQUIRED, OUTAGE),
+	}))
+}
+
+func TestEscalate(t *testing.T) {
+	policy := Escalate(HCv3, WEAK, 2, MAJOR)
+	require.Equal(t, MINOR, policy.Aggregate([]Result{
+		result("a", WEAK, MINOR),
+		result("b", WEAK, OK),
+	}))
+	require.Equal(t, MAJOR, policy.Aggregate([]Result{
+		result("a", WEAK, MINOR),
+		result("b", WEAK, MINOR),
+	}))
+	require.Equal(t, OUTAGE, policy.Aggregate([]Result{
+		result("a", WEAK, MINOR),
+		result("b", WEAK, MINOR),
+		result("c", REQUIRED, OUTAGE),
+	}))
+}
+
+func TestBasicDependencySet_WithAggregationPolicy(t *testing.T) {
+	deps := NewBasicDependencySetWithOptions(context.Background(),
+		WithAggregationPolicy(Escalate(HCv3, WEAK, 2, MAJOR)),
+	)
+	defer deps.Close()
+	s := deps.Subscribe()
+	defer s.Close()
+
+	failing := func(ctx context.Context) Health {
+		return NewHealth(OUTAGE, "down")
+	}
+	deps.Register(
+		NewMonitor("first", "", "", WEAK, failing, nil),
+		NewMonitor("second", "", "", WEAK, failing, nil),
+	)
+	deps.waitUntilInitialRun()
+
+	require.Equal(t, MAJOR, deps.Background().Overall())
+	require.Equal(t, MAJOR, deps.Live().Overall())
+
+	var overall []Status
+	for len(overall) < 2 {
+		if e := receive(t, s); e.Kind == OverallTransition {
+			overall = append(overall, e.Next)
+		}
+	}
+	require.Equal(t, []Status{MINOR, MAJOR}, overall)
+}
+
+func TestEscalate_noFailures(t *testing.T) {
+	policy := Escalate(HCv3, WEAK, 0, OUTAGE)
+	require.Equal(t, OK, policy.Aggregate(nil))
+	require.Equal(t, OUTAGE, policy.Aggregate([]Result{result("a", WEAK, MINOR)}))
+}
@@ -0,0 +1,155 @@
+// Package clock provides a source of time which can be replaced
+// in tests, so that periods and timeouts do not require real sleeps.
+package clock
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"oss.indeed.com/go/libtime"
+)
+
+// A Clock is a libtime.Clock which also creates Timers that fire
+// according to it.
+type Clock interface {
+	libtime.Clock
+
+	// NewTimer creates a Timer which fires after d has passed on this Clock.
+	NewTimer(d time.Duration) Timer
+}
+
+// A Timer sends the time on its channel once, after it expires,
+// unless it is stopped first.
+type Timer interface {
+	C() <-chan time.Time
+	Stop() bool
+}
+
+// System is the Clock of the system, as provided by the time package.
+var System Clock = system{libtime.SystemClock()}
+
+type system struct {
+	libtime.Clock
+}
+
+func (system) NewTimer(d time.Duration) Timer {
+	return systemTimer{time.NewTimer(d)}
+}
+
+type systemTimer struct {
+	*time.Timer
+}
+
+func (t systemTimer) C() <-chan time.Time {
+	return t.Timer.C
+}
+
+// AfterFunc is like time.AfterFunc, except that d is measured by c. As with
+// time.AfterFunc, the channel of the returned Timer is nil, and f runs in its
+// own goroutine unless the Timer is stopped first.
+func AfterFunc(c Clock, d time.Duration, f func()) Timer {
+	if _, ok := c.(system); ok {
+		return funcTimer{stop: time.AfterFunc(d, f).Stop}
+	}
+
+	timer := c.NewTimer(d)
+	stopped := make(chan struct{})
+	go func() {
+		select {
+		case <-timer.C():
+			f()
+		case <-stopped:
+		}
+	}()
+	return funcTimer{stop: func() bool {
+		if !timer.Stop() {
+			return false
+		}
+		close(stopped)
+		return true
+	}}
+}
+
+type funcTimer struct {
+	stop func() bool
+}
+
+func (funcTimer) C() <-chan time.Time {
+	return nil
+}
+
+func (t funcTimer) Stop() bool {
+	return t.stop()
+}
+
+type contextKey struct{}
+
+// NewContext returns a copy of ctx which carries c, so that code called
+// with ctx, such as a check, can measure time the same as its caller.
+func NewContext(ctx context.Context, c Clock) context.Context {
+	return context.WithValue(ctx, contextKey{}, c)
+}
+
+// FromContext returns the Clock carried by ctx, if any.
+func FromContext(ctx context.Context) (Clock, bool) {
+	c, ok := ctx.Value(contextKey{}).(Clock)
+	return c, ok
+}
+
+// WithDeadline is like context.WithDeadline, except that the deadline
+// is measured by c.
+func WithDeadline(parent context.Context, c Clock, deadline time.Time) (context.Context, context.CancelFunc) {
+	if _, ok := c.(system); ok {
+		return context.WithDeadline(parent, deadline)
+	}
+
+	ctx, cancel := context.WithCancel(parent)
+	dc := &deadlineContext{Context: ctx, deadline: deadline}
+	timer := c.NewTimer(deadline.Sub(c.Now()))
+	go func() {
+		select {
+		case <-timer.C():
+			dc.expire()
+			cancel()
+		case <-ctx.Done():
+		}
+	}()
+	return dc, func() {
+		timer.Stop()
+		cancel()
+	}
+}
+
+// deadlineContext is a context which is cancelled when a Clock
+// other than System reaches its deadline.
+type deadlineContext struct {
+	context.Context
+	deadline time.Time
+
+	lock    sync.Mutex
+	expired bool
+}
+
+func (c *deadlineContext) Deadline() (time.Time, bool) {
+	return c.deadline, true
+}
+
+func (c *deadlineContext) Err() error {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	if c.expired {
+		return context.DeadlineExceeded
+	}
+	return c.Context.Err()
+}
+
+func (c *deadlineContext) expire() {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	if c.Context.Err() == nil {
+		c.expired = true
+	}
+}
@@ -0,0 +1,114 @@
+package clock
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+var epoch = time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+
+func fired(t Timer) bool {
+	select {
+	case <-t.C():
+		return true
+	default:
+		return false
+	}
+}
+
+func TestFake_Advance(t *testing.T) {
+	f := NewFake(epoch)
+	later := f.NewTimer(2 * time.Second)
+	sooner := f.NewTimer(time.Second)
+	stopped := f.NewTimer(time.Second)
+	require.Equal(t, 3, f.Timers())
+
+	require.True(t, stopped.Stop())
+	require.False(t, stopped.Stop())
+
+	f.Advance(time.Second)
+	require.Equal(t, epoch.Add(time.Second), f.Now())
+	require.Equal(t, time.Second, f.Since(epoch))
+	require.Equal(t, 1000, f.SinceMS(epoch))
+	require.True(t, fired(sooner))
+	require.False(t, fired(later))
+	require.False(t, fired(stopped))
+	require.Equal(t, 1, f.Timers())
+
+	f.Advance(time.Hour)
+	require.Equal(t, epoch.Add(2*time.Second), <-later.C(), "a timer fires at its deadline")
+	require.Zero(t, f.Timers())
+}
+
+func TestFake_NewTimer_expired(t *testing.T) {
+	f := NewFake(epoch)
+	require.True(t, fired(f.NewTimer(0)))
+	require.Zero(t, f.Timers())
+}
+
+func TestFake_BlockUntil(t *testing.T) {
+	f := NewFake(epoch)
+	go f.NewTimer(time.Second)
+	f.BlockUntil(1)
+	require.Equal(t, 1, f.Timers())
+}
+
+func TestWithDeadline(t *testing.T) {
+	f := NewFake(epoch)
+	ctx, cancel := WithDeadline(context.Background(), f, epoch.Add(time.Second))
+	defer cancel()
+
+	deadline, ok := ctx.Deadline()
+	require.True(t, ok)
+	require.Equal(t, epoch.Add(time.Second), deadline)
+
+	f.BlockUntil(1)
+	require.NoError(t, ctx.Err())
+
+	f.Advance(time.Second)
+	<-ctx.Done()
+	require.Equal(t, context.DeadlineExceeded, ctx.Err())
+}
+
+func TestWithDeadline_cancel(t *testing.T) {
+	f := NewFake(epoch)
+	ctx, cancel := WithDeadline(context.Background(), f, epoch.Add(time.Second))
+	cancel()
+
+	<-ctx.Done()
+	require.Equal(t, context.Canceled, ctx.Err())
+}
+
+func TestWithDeadline_System(t *testing.T) {
+	ctx, cancel := WithDeadline(context.Background(), System, time.Now().Add(time.Millisecond))
+	defer cancel()
+
+	<-ctx.Done()
+	require.Equal(t, context.DeadlineExceeded, ctx.Err())
+}
+
+func TestAfterFunc(t *testing.T) {
+	f := NewFake(epoch)
+	called := make(chan struct{})
+	AfterFunc(f, time.Second, func() { close(called) })
+	stopped := AfterFunc(f, time.Second, func() { t.Error("a stopped timer does not call its function") })
+	require.True(t, stopped.Stop())
+	require.False(t, stopped.Stop())
+
+	f.Advance(time.Second)
+	<-called
+	require.Zero(t, f.Timers())
+}
+
+func TestFromContext(t *testing.T) {
+	_, ok := FromContext(context.Background())
+	require.False(t, ok)
+
+	f := NewFake(epoch)
+	c, ok := FromContext(NewContext(context.Background(), f))
+	require.True(t, ok)
+	require.Equal(t, f, c)
+}
@@ -0,0 +1,131 @@
+package clock
+
+import (
+	"sort"
+	"sync"
+	"time"
+
+	"oss.indeed.com/go/libtime"
+)
+
+// Fake is a Clock which only moves when told to, by Advance or Set.
+type Fake struct {
+	lock   sync.Mutex
+	added  *sync.Cond // signalled when a Timer is added
+	now    time.Time
+	timers []*fakeTimer
+}
+
+// NewFake creates a Fake Clock which starts at now.
+func NewFake(now time.Time) *Fake {
+	f := &Fake{now: now}
+	f.added = sync.NewCond(&f.lock)
+	return f
+}
+
+// Now returns the time of f.
+func (f *Fake) Now() time.Time {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	return f.now
+}
+
+// Since returns how long it has been since t, according to f.
+func (f *Fake) Since(t time.Time) time.Duration {
+	return f.Now().Sub(t)
+}
+
+// SinceMS is like Since, in milliseconds.
+func (f *Fake) SinceMS(t time.Time) int {
+	return int(libtime.DurationToMillis(f.Since(t)))
+}
+
+// NewTimer creates a Timer which fires once f is advanced by d.
+func (f *Fake) NewTimer(d time.Duration) Timer {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	t := &fakeTimer{
+		fake:     f,
+		c:        make(chan time.Time, 1),
+		deadline: f.now.Add(d),
+	}
+	if d <= 0 {
+		t.c <- f.now
+		return t
+	}
+	f.timers = append(f.timers, t)
+	f.added.Broadcast()
+	return t
+}
+
+// Advance moves f forward by d, firing the Timers which expire
+// on the way, in order.
+func (f *Fake) Advance(d time.Duration) {
+	f.Set(f.Now().Add(d))
+}
+
+// Set moves f to now, firing the Timers which expire on the way, in order.
+func (f *Fake) Set(now time.Time) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	sort.SliceStable(f.timers, func(i, j int) bool {
+		return f.timers[i].deadline.Before(f.timers[j].deadline)
+	})
+
+	f.now = now
+	pending := f.timers[:0]
+	for _, t := range f.timers {
+		if t.deadline.After(now) {
+			pending = append(pending, t)
+			continue
+		}
+		t.c <- t.deadline
+	}
+	f.timers = pending
+}
+
+// Timers returns the number of Timers of f which have not fired or
+// been stopped yet.
+func (f *Fake) Timers() int {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	return len(f.timers)
+}
+
+// BlockUntil blocks until f has at least n Timers which have not fired or
+// been stopped yet, such as when the code under test is waiting on them.
+func (f *Fake) BlockUntil(n int) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	for len(f.timers) < n {
+		f.added.Wait()
+	}
+}
+
+type fakeTimer struct {
+	fake     *Fake
+	c        chan time.Time
+	deadline time.Time
+}
+
+func (t *fakeTimer) C() <-chan time.Time {
+	return t.c
+}
+
+func (t *fakeTimer) Stop() bool {
+	t.fake.lock.Lock()
+	defer t.fake.lock.Unlock()
+
+	for i, pending := range t.fake.timers {
+		if pending == t {
+			t.fake.timers = append(t.fake.timers[:i], t.fake.timers[i+1:]...)
+			return true
+		}
+	}
+	return false
+}
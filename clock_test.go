@@ -0,0 +1,133 @@
+package libhealth
+
+import (
+	"context"
+	"encoding/json"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"oss.indeed.com/go/libhealth/clock"
+)
+
+var fakeEpoch = time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+
+func TestBasicDependencySet_WithSetClock(t *testing.T) {
+	fake := clock.NewFake(fakeEpoch)
+	deps := NewBasicDependencySetWithOptions(context.Background(), WithSetClock(fake))
+	defer deps.Close()
+
+	var count int32
+	deps.Register(countingMonitor("counting", &count, WithPeriod(time.Minute), WithClock(fake)))
+	deps.waitUntilInitialRun()
+	require.Equal(t, int32(1), atomic.LoadInt32(&count))
+	require.Equal(t, fakeEpoch, deps.Background().results[0].Time)
+
+	fake.BlockUntil(1)
+	fake.Advance(59 * time.Second)
+	require.Equal(t, int32(1), atomic.LoadInt32(&count))
+
+	fake.Advance(time.Second)
+	require.Eventually(t, func() bool {
+		return atomic.LoadInt32(&count) == 2
+	}, time.Second, time.Millisecond)
+}
+
+func TestBasicDependencySet_WithSetClock_timeout(t *testing.T) {
+	fake := clock.NewFake(fakeEpoch)
+	deps := NewBasicDependencySetWithOptions(context.Background(), WithSetClock(fake))
+	defer deps.Close()
+
+	// the check only returns once the timeout has been observed
+	release := make(chan struct{})
+	defer close(release)
+	deps.Register(NewMonitorWithOptions("blocking", "", "", REQUIRED, func(ctx context.Context) Health {
+		<-release
+		return NewHealth(OK, "okay")
+	}, WithTimeout(time.Minute), WithPeriod(0)))
+
+	fake.BlockUntil(1)
+	fake.Advance(time.Minute)
+	deps.waitUntilInitialRun()
+
+	result := deps.Background().results[0]
+	require.Equal(t, OUTAGE, result.Status)
+	require.Equal(t, Message("healthcheck timed out"), result.Message)
+}
+
+func TestPrivate_WithHandlerClock(t *testing.T) {
+	fake := clock.NewFake(fakeEpoch)
+	p := NewPrivate("test", NewBasicDependencySet(), WithHandlerClock(fake))
+	fake.Advance(90 * time.Second)
+
+	body, _ := p.generate(false, "host")
+	var result PrivateResult
+	require.NoError(t, json.Unmarshal(body, &result))
+	require.Equal(t, "1m30s", result.AppUpTimeReadable)
+	require.Equal(t, "90", result.AppUpTimeSeconds)
+}
+
+func TestSubscription_debounce_WithSetClock(t *testing.T) {
+	fake := clock.NewFake(fakeEpoch)
+	b := bus{clock: fake}
+	s := b.subscribe(WithDebounce(time.Minute))
+	defer s.Close()
+
+	b.publish(Event{Kind: MonitorTransition, Name: "failing", Prev: OK, Next: OUTAGE})
+	require.Equal(t, 1, fake.Timers())
+	require.Empty(t, s.Events())
+
+	fake.Advance(time.Minute)
+	e := receive(t, s)
+	require.Equal(t, "failing", e.Name)
+	require.Equal(t, OUTAGE, e.Next)
+}
+
+func TestAllOf_WithSetClock(t *testing.T) {
+	fake := clock.NewFake(fakeEpoch)
+	deps := NewBasicDependencySetWithOptions(context.Background(), WithSetClock(fake))
+	defer deps.Close()
+
+	release := make(chan struct{})
+	defer close(release)
+	deps.Register(NewMonitorWithOptions("composite", "", "", REQUIRED, AllOf(Member{
+		Name: "blocking",
+		Checker: func(ctx context.Context) Health {
+			<-release
+			return NewHealth(OK, "okay")
+		},
+		Timeout: time.Second,
+	}), WithTimeout(time.Minute), WithPeriod(0)))
+
+	// the deadlines of the check and of its member
+	fake.BlockUntil(2)
+	fake.Advance(time.Second)
+	deps.waitUntilInitialRun()
+
+	result := deps.Background().results[0]
+	require.Equal(t, OUTAGE, result.Status)
+	require.Contains(t, string(result.Message), "blocking is OUTAGE: healthcheck timed out")
+}
+
+func TestTrigger_WithHandlerClock(t *testing.T) {
+	fake := clock.NewFake(fakeEpoch)
+	deps := NewBasicDependencySetWithOptions(context.Background(), WithSetClock(fake))
+	defer deps.Close()
+	deps.Register(NewMonitorWithOptions("checked", "", "", REQUIRED, sequenceChecker(OK, OK), WithClock(fake)))
+	deps.waitUntilInitialRun()
+
+	body, code := NewTrigger(deps, WithHandlerClock(fake)).generate(context.Background(), "checked")
+	require.Equal(t, 200, code)
+	var component Component
+	require.NoError(t, json.Unmarshal(body, &component))
+	require.Equal(t, fakeEpoch.UnixNano()/int64(time.Millisecond), component.Timestamp)
+}
+
+func TestNewHealthAt(t *testing.T) {
+	h := NewHealthAt(MAJOR, "degraded", fakeEpoch)
+	require.Equal(t, MAJOR, h.Status)
+	require.Equal(t, fakeEpoch, h.Time)
+	require.Equal(t, Message("degraded"), h.Message)
+}
@@ -0,0 +1,152 @@
+package libhealth
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"oss.indeed.com/go/libhealth/clock"
+)
+
+// A Member is a named HealthChecker which is part of a composite
+// HealthChecker created by AllOf, AnyOf or Quorum.
+type Member struct {
+	Name    string
+	Checker HealthChecker
+	Timeout time.Duration // no limit other than that of the composite if 0
+}
+
+// Members creates a Member of each of monitors, which is checked by calling
+// its Check method within its Timeout.
+func Members(monitors ...HealthMonitor) []Member {
+	members := make([]Member, 0, len(monitors))
+	for _, monitor := range monitors {
+		members = append(members, Member{
+			Name:    monitor.Name(),
+			Checker: monitor.Check,
+			Timeout: monitor.Timeout(),
+		})
+	}
+	return members
+}
+
+// AllOf creates a HealthChecker which checks every one of members
+// concurrently, and reports the worst of their Statuses.
+func AllOf(members ...Member) HealthChecker {
+	return Quorum(len(members), members...)
+}
+
+// AnyOf creates a HealthChecker which checks every one of members
+// concurrently, and reports the best of their Statuses.
+func AnyOf(members ...Member) HealthChecker {
+	return Quorum(1, members...)
+}
+
+// Quorum creates a HealthChecker which checks every one of members
+// concurrently, and reports the best Status that at least k of them share,
+// so that the check is OK while at least k members are OK. For example,
+// with three replicas of a dependency,
+//
+//	Quorum(2, replicas...)
+//
+// only fails when fewer than two of them are OK.
+//
+// Members which do not complete before the context of the check is done,
+// or their own Timeout, as measured by the Clock carried by the context
+// (see clock.FromContext), are reported as an OUTAGE, as are members which
+// panic. The Status and message of each member are listed in the message
+// and Details of the Health.
+func Quorum(k int, members ...Member) HealthChecker {
+	return func(ctx context.Context) Health {
+		healths := checkMembers(ctx, members)
+
+		statuses := make([]Status, len(healths))
+		for i, h := range healths {
+			statuses[i] = h.Status
+		}
+		sort.Slice(statuses, func(i, j int) bool {
+			return statuses[i].BetterThan(statuses[j])
+		})
+
+		status := OUTAGE
+		switch {
+		case len(statuses) == 0 || k <= 0:
+			status = OK
+		case k <= len(statuses):
+			status = statuses[k-1]
+		}
+
+		ok := 0
+		listed := make([]string, 0, len(members))
+		for i, h := range healths {
+			if h.SameAs(OK) {
+				ok++
+			}
+			listed = append(listed, fmt.Sprintf("%s is %s: %s", members[i].Name, h.Status, h.Message))
+		}
+
+		message := fmt.Sprintf("%d of %d members are OK, %d required", ok, len(members), k)
+		if len(listed) > 0 {
+			message += "; " + strings.Join(listed, "; ")
+		}
+
+		composite := NewHealthAt(status, message, contextClock(ctx).Now())
+		for i, h := range healths {
+			composite = composite.With(members[i].Name, h.Status.String())
+		}
+		return composite
+	}
+}
+
+// checkMembers checks members concurrently, returning their Health in the
+// same order.
+func checkMembers(ctx context.Context, members []Member) []Health {
+	healths := make([]Health, len(members))
+	done := make(chan int, len(members))
+	for i := range members {
+		go func(i int) {
+			healths[i] = checkMember(ctx, members[i])
+			done <- i
+		}(i)
+	}
+	for range members {
+		<-done
+	}
+	return healths
+}
+
+func checkMember(ctx context.Context, member Member) Health {
+	c := contextClock(ctx)
+	if member.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = clock.WithDeadline(ctx, c, c.Now().Add(member.Timeout))
+		defer cancel()
+	}
+
+	healthc := make(chan Health, 1)
+	go func() {
+		defer func() {
+			if value := recover(); value != nil {
+				healthc <- NewHealthAt(OUTAGE, fmt.Sprintf("healthcheck panicked: %v", value), c.Now())
+			}
+		}()
+		healthc <- member.Checker(ctx)
+	}()
+
+	select {
+	case h := <-healthc:
+		return h
+	case <-ctx.Done():
+		return NewHealthAt(OUTAGE, "healthcheck timed out", c.Now())
+	}
+}
+
+// contextClock is the Clock carried by ctx, or clock.System.
+func contextClock(ctx context.Context) clock.Clock {
+	if c, ok := clock.FromContext(ctx); ok {
+		return c
+	}
+	return clock.System
+}
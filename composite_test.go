@@ -0,0 +1,83 @@
+package libhealth
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func member(name string, status Status) Member {
+	return Member{Name: name, Checker: func(ctx context.Context) Health {
+		return NewHealth(status, "checked "+name)
+	}}
+}
+
+func TestQuorum(t *testing.T) {
+	replicas := []Member{member("a", OK), member("b", OUTAGE), member("c", OK)}
+
+	h := Quorum(2, replicas...)(context.Background())
+	require.Equal(t, OK, h.Status)
+	require.Equal(t, Message("2 of 3 members are OK, 2 required; "+
+		"a is OK: checked a; b is OUTAGE: checked b; c is OK: checked c"), h.Message)
+	require.Equal(t, Details{"a": "OK", "b": "OUTAGE", "c": "OK"}, h.Details)
+
+	require.Equal(t, OUTAGE, Quorum(3, replicas...)(context.Background()).Status)
+	require.Equal(t, OUTAGE, Quorum(4, replicas...)(context.Background()).Status)
+}
+
+func TestAllOf_AnyOf(t *testing.T) {
+	members := []Member{member("a", MINOR), member("b", MAJOR), member("c", OK)}
+
+	require.Equal(t, MAJOR, AllOf(members...)(context.Background()).Status)
+	require.Equal(t, OK, AnyOf(members...)(context.Background()).Status)
+	require.Equal(t, OK, AllOf()(context.Background()).Status)
+}
+
+func TestQuorum_timeoutAndPanic(t *testing.T) {
+	release := make(chan struct{})
+	defer close(release)
+	blocking := Member{Name: "blocking", Checker: func(ctx context.Context) Health {
+		<-release
+		return NewHealth(OK, "released")
+	}}
+	slow := Member{Name: "slow", Timeout: time.Millisecond, Checker: func(ctx context.Context) Health {
+		<-ctx.Done()
+		time.Sleep(10 * time.Millisecond)
+		return NewHealth(OK, "too late")
+	}}
+	panicking := Member{Name: "panicking", Checker: func(ctx context.Context) Health {
+		panic("oh no")
+	}}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	h := AnyOf(blocking, slow, panicking)(ctx)
+	require.Equal(t, OUTAGE, h.Status)
+	require.Equal(t, Message("0 of 3 members are OK, 1 required; "+
+		"blocking is OUTAGE: healthcheck timed out; "+
+		"slow is OUTAGE: healthcheck timed out; "+
+		"panicking is OUTAGE: healthcheck panicked: oh no"), h.Message)
+}
+
+func TestMembers(t *testing.T) {
+	monitors := []HealthMonitor{
+		NewMonitor("a", "", "", WEAK, func(ctx context.Context) Health {
+			return NewHealth(OK, "okay")
+		}, nil),
+		NewMonitor("b", "", "", WEAK, func(ctx context.Context) Health {
+			return NewHealth(OUTAGE, "down")
+		}, nil),
+	}
+
+	deps := NewBasicDependencySet(NewMonitor("replicas", "", "", REQUIRED, Quorum(1, Members(monitors...)...), nil))
+	defer deps.Close()
+	deps.waitUntilInitialRun()
+
+	results := deps.Background().results
+	require.Len(t, results, 1)
+	require.Equal(t, OK, results[0].Status)
+	require.Equal(t, Details{"a": "OK", "b": "OUTAGE"}, results[0].Details)
+}
@@ -0,0 +1,125 @@
+package config
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"os/exec"
+	"strconv"
+	"strings"
+
+	"oss.indeed.com/go/libhealth"
+)
+
+// A builder validates the params of a type of check, returning how to build
+// its HealthMonitor.
+type builder func(params *fields) func(m monitor) libhealth.HealthMonitor
+
+var builders = map[string]builder{
+	"http":       httpBuilder,
+	"tcp":        tcpBuilder,
+	"exec":       execBuilder,
+	"transitive": transitiveBuilder,
+}
+
+// client of http checks, which are limited by the timeout of their monitor.
+var client = &http.Client{}
+
+func checker(check libhealth.HealthChecker) func(m monitor) libhealth.HealthMonitor {
+	return func(m monitor) libhealth.HealthMonitor {
+		return libhealth.NewMonitorWithOptions(m.name, m.description, m.docURL, m.urgency, check, m.options...)
+	}
+}
+
+func httpBuilder(params *fields) func(m monitor) libhealth.HealthMonitor {
+	target := params.url("url")
+	method := strings.ToUpper(params.str("method", false))
+	if method == "" {
+		method = http.MethodGet
+	}
+	status := http.StatusOK
+	if value, node := params.scalar("status", false); node != nil {
+		code, err := strconv.Atoi(value)
+		if err != nil || code < 100 || code > 599 {
+			params.p.errorf(node, "invalid status %q, must be an HTTP status code", value)
+		}
+		status = code
+	}
+
+	return checker(libhealth.FromError(func(ctx context.Context) error {
+		req, err := http.NewRequestWithContext(ctx, method, target, http.NoBody)
+		if err != nil {
+			return err
+		}
+		resp, err := client.Do(req)
+		if err != nil {
+			return err
+		}
+		defer resp.Body.Close()
+
+		if resp.StatusCode != status {
+			return fmt.Errorf("%s %s: %s", method, target, resp.Status)
+		}
+		return nil
+	}))
+}
+
+func tcpBuilder(params *fields) func(m monitor) libhealth.HealthMonitor {
+	address, node := params.scalar("address", true)
+	if node != nil {
+		if _, port, err := net.SplitHostPort(address); err != nil || port == "" {
+			params.p.errorf(node, "invalid address %q, must be host:port", address)
+		}
+	}
+
+	return checker(libhealth.FromError(func(ctx context.Context) error {
+		var dialer net.Dialer
+		conn, err := dialer.DialContext(ctx, "tcp", address)
+		if err != nil {
+			return err
+		}
+		return conn.Close()
+	}))
+}
+
+func execBuilder(params *fields) func(m monitor) libhealth.HealthMonitor {
+	command := params.list("command", true)
+
+	return checker(libhealth.FromError(func(ctx context.Context) error {
+		output, err := exec.CommandContext(ctx, command[0], command[1:]...).CombinedOutput() //nolint:gosec // the configuration file is trusted like code
+		if err != nil {
+			if trimmed := strings.TrimSpace(string(output)); trimmed != "" {
+				return fmt.Errorf("%w: %s", err, trimmed)
+			}
+			return err
+		}
+		return nil
+	}))
+}
+
+func transitiveBuilder(params *fields) func(m monitor) libhealth.HealthMonitor {
+	target := params.url("url")
+
+	return func(m monitor) libhealth.HealthMonitor {
+		transitive := libhealth.TransitiveMonitor(target, m.name, m.description, m.docURL, m.urgency, nil)
+		for _, option := range m.options {
+			option(transitive)
+		}
+		return transitive
+	}
+}
+
+// url returns the value of key, which must be an absolute http or https URL.
+func (f *fields) url(key string) string {
+	value, node := f.scalar(key, true)
+	if node == nil {
+		return ""
+	}
+	u, err := url.Parse(value)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		f.p.errorf(node, "invalid %s %q, must be an http or https URL", key, value)
+	}
+	return value
+}
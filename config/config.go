@@ -0,0 +1,120 @@
+// Package config loads HealthMonitors from a configuration file, so that
+// common dependencies can be monitored, and their urgencies and periods
+// changed, without a code change.
+//
+// A configuration file is YAML, or JSON, such as
+//
+//	monitors:
+//	  - name: database
+//	    description: the primary database
+//	    docURL: https://example.com/runbooks/database
+//	    urgency: REQUIRED
+//	    period: 30s
+//	    timeout: 5s
+//	    type: tcp
+//	    params:
+//	      address: db.example.com:5432
+//
+// The types of checks and their params are
+//   - http: url, and optionally method (GET) and status (200) of the response
+//   - tcp: address to connect to, as host:port
+//   - exec: command to run, as a list of the program and its arguments,
+//     which is OK when it exits with 0
+//   - transitive: url of the healthcheck of another service, as with
+//     libhealth.TransitiveMonitor
+//
+// The period and timeout are optional, as formatted for time.ParseDuration.
+//
+// An exec check runs whatever command the configuration file gives it, with
+// the privileges of the service, so a configuration file must be trusted as
+// much as the code of the service itself.
+package config
+
+import (
+	"fmt"
+	"io/ioutil"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"oss.indeed.com/go/libhealth"
+)
+
+// An Error is a problem found with a configuration file.
+type Error struct {
+	File    string // empty unless loaded from a file
+	Line    int
+	Message string
+}
+
+func (e *Error) Error() string {
+	if e.File == "" {
+		return fmt.Sprintf("line %d: %s", e.Line, e.Message)
+	}
+	return fmt.Sprintf("%s:%d: %s", e.File, e.Line, e.Message)
+}
+
+// Errors are all of the problems found with a configuration file, in the
+// order of their lines.
+type Errors []*Error
+
+func (e Errors) Error() string {
+	messages := make([]string, 0, len(e))
+	for _, err := range e {
+		messages = append(messages, err.Error())
+	}
+	return strings.Join(messages, "\n")
+}
+
+// Parse creates the HealthMonitors configured by data. If data is not
+// valid, the returned error is either Errors, listing every problem
+// found, or a syntax error.
+func Parse(data []byte) ([]libhealth.HealthMonitor, error) {
+	var root yaml.Node
+	if err := yaml.Unmarshal(data, &root); err != nil {
+		return nil, err
+	}
+
+	p := new(parser)
+	monitors := p.document(&root)
+	if len(p.errs) > 0 {
+		sort.SliceStable(p.errs, func(i, j int) bool {
+			return p.errs[i].Line < p.errs[j].Line
+		})
+		return nil, p.errs
+	}
+	return monitors, nil
+}
+
+// Load creates the HealthMonitors configured by the file at path, as with
+// Parse.
+func Load(path string) ([]libhealth.HealthMonitor, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	monitors, err := Parse(data)
+	if errs, ok := err.(Errors); ok {
+		for _, e := range errs {
+			e.File = path
+		}
+		return nil, errs
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return monitors, nil
+}
+
+// Register creates the HealthMonitors configured by the file at path, and
+// registers them to set. If the file is not valid, none are registered.
+func Register(set libhealth.DependencySet, path string) error {
+	monitors, err := Load(path)
+	if err != nil {
+		return err
+	}
+	set.Register(monitors...)
+	return nil
+}
@@ -0,0 +1,207 @@
+package config
+
+import (
+	"context"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"oss.indeed.com/go/libhealth"
+)
+
+func TestParse(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/missing" {
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer listener.Close()
+
+	monitors, err := Parse([]byte(`
+monitors:
+  - name: website
+    description: the website
+    docURL: https://example.com/website
+    urgency: required
+    period: 1m
+    timeout: 5s
+    type: http
+    params:
+      url: ` + server.URL + `
+  - name: missing
+    urgency: WEAK
+    type: http
+    params:
+      url: ` + server.URL + `/missing
+      method: head
+      status: 404
+  - name: database
+    urgency: STRONG
+    type: tcp
+    params:
+      address: ` + listener.Addr().String() + `
+  - name: upstream
+    urgency: NONE
+    period: 10s
+    type: transitive
+    params:
+      url: ` + server.URL + `
+`))
+	require.NoError(t, err)
+	require.Len(t, monitors, 4)
+
+	website := monitors[0]
+	require.Equal(t, "website", website.Name())
+	require.Equal(t, "the website", website.Description())
+	require.Equal(t, "https://example.com/website", website.Documentation())
+	require.Equal(t, libhealth.REQUIRED, website.Urgency())
+	require.Equal(t, time.Minute, website.Period())
+	require.Equal(t, 5*time.Second, website.Timeout())
+	require.Equal(t, 10*time.Second, monitors[3].Period())
+
+	for _, monitor := range monitors {
+		h := monitor.Check(context.Background())
+		require.Equal(t, libhealth.OK, h.Status, "%s: %s", monitor.Name(), h.Message)
+	}
+}
+
+func TestParse_failing(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	address := listener.Addr().String()
+	require.NoError(t, listener.Close())
+
+	monitors, err := Parse([]byte(`{"monitors": [
+  {"name": "database", "urgency": "REQUIRED", "type": "tcp", "params": {"address": "` + address + `"}}
+]}`))
+	require.NoError(t, err)
+	require.Len(t, monitors, 1)
+
+	h := monitors[0].Check(context.Background())
+	require.Equal(t, libhealth.OUTAGE, h.Status)
+	require.Error(t, h.Err)
+}
+
+func TestParse_exec(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("requires a shell")
+	}
+
+	monitors, err := Parse([]byte(`
+monitors:
+  - name: passing
+    urgency: WEAK
+    type: exec
+    params:
+      command: [sh, -c, "exit 0"]
+  - name: failing
+    urgency: WEAK
+    type: exec
+    params:
+      command: [sh, -c, "echo broken; exit 1"]
+`))
+	require.NoError(t, err)
+	require.Len(t, monitors, 2)
+
+	require.Equal(t, libhealth.OK, monitors[0].Check(context.Background()).Status)
+	h := monitors[1].Check(context.Background())
+	require.Equal(t, libhealth.OUTAGE, h.Status)
+	require.Equal(t, libhealth.Message("exit status 1: broken"), h.Message)
+}
+
+func TestParse_invalid(t *testing.T) {
+	_, err := Parse([]byte(`
+monitors:
+  - name: website
+    urgency: sometimes
+    period: soon
+    type: http
+    params:
+      url: example.com
+      status: 999
+  - name: website
+    urgency: WEAK
+    type: carrier-pigeon
+    params: {}
+  - urgency: WEAK
+    type: tcp
+    typo: true
+    params:
+      address: localhost
+  - name: script
+    urgency: WEAK
+    type: exec
+    params:
+      command: []
+  - name: noparams
+    urgency: WEAK
+    type: transitive
+`))
+	require.Equal(t, Errors{
+		{Line: 4, Message: `unknown urgency "sometimes"`},
+		{Line: 5, Message: `invalid period: time: invalid duration "soon"`},
+		{Line: 8, Message: `invalid url "example.com", must be an http or https URL`},
+		{Line: 9, Message: `invalid status "999", must be an HTTP status code`},
+		{Line: 10, Message: `duplicate monitor "website", first configured on line 3`},
+		{Line: 12, Message: `unknown type "carrier-pigeon", must be one of http, tcp, exec or transitive`},
+		{Line: 14, Message: `missing name`},
+		{Line: 16, Message: `unknown key "typo"`},
+		{Line: 18, Message: `invalid address "localhost", must be host:port`},
+		{Line: 23, Message: `command must not be empty`},
+		{Line: 24, Message: `missing params`},
+	}, err)
+}
+
+func TestParse_syntax(t *testing.T) {
+	_, err := Parse([]byte("monitors: [\n"))
+	require.Error(t, err)
+	_, isErrors := err.(Errors)
+	require.False(t, isErrors)
+
+	_, err = Parse([]byte("monitors: nope\n"))
+	require.EqualError(t, err, "line 1: monitors must be a list")
+
+	monitors, err := Parse(nil)
+	require.NoError(t, err)
+	require.Empty(t, monitors)
+}
+
+func TestRegister(t *testing.T) {
+	dir, err := ioutil.TempDir("", "config")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, "monitors.yaml")
+	require.NoError(t, ioutil.WriteFile(path, []byte(`
+monitors:
+  - name: upstream
+    urgency: WEAK
+    type: transitive
+    params:
+      url: http://127.0.0.1:1/health
+`), 0600))
+
+	set := libhealth.NewBasicDependencySet()
+	defer set.Close()
+	require.NoError(t, Register(set, path))
+	require.Equal(t, libhealth.MINOR, set.Live().Overall())
+
+	require.NoError(t, ioutil.WriteFile(path, []byte("monitors:\n  - name: broken\n"), 0600))
+	err = Register(set, path)
+	require.EqualError(t, err, path+":2: missing urgency\n"+path+":2: missing type\n"+path+":2: missing params")
+
+	_, err = Load(filepath.Join(dir, "missing.yaml"))
+	require.True(t, os.IsNotExist(err))
+}
@@ -0,0 +1,260 @@
+package config
+
+import (
+	"fmt"
+	"time"
+
+	"gopkg.in/yaml.v3"
+
+	"oss.indeed.com/go/libhealth"
+)
+
+// parser builds HealthMonitors from a YAML document, collecting every
+// problem it finds along the way.
+type parser struct {
+	errs Errors
+}
+
+func (p *parser) errorf(node *yaml.Node, format string, args ...interface{}) {
+	p.errs = append(p.errs, &Error{Line: node.Line, Message: fmt.Sprintf(format, args...)})
+}
+
+func (p *parser) document(root *yaml.Node) []libhealth.HealthMonitor {
+	if root.Kind == 0 {
+		return nil // empty
+	}
+
+	top := p.fields(resolve(root.Content[0]))
+	if top == nil {
+		return nil
+	}
+	list := top.node("monitors", true)
+	top.unknown()
+	if list == nil {
+		return nil
+	}
+	if list.Kind != yaml.SequenceNode {
+		p.errorf(list, "monitors must be a list")
+		return nil
+	}
+
+	names := make(map[string]int, len(list.Content))
+	monitors := make([]libhealth.HealthMonitor, 0, len(list.Content))
+	for _, item := range list.Content {
+		item = resolve(item)
+		monitor, name := p.monitor(item)
+		if name != "" {
+			if line, exists := names[name]; exists {
+				p.errorf(item, "duplicate monitor %q, first configured on line %d", name, line)
+			}
+			names[name] = item.Line
+		}
+		if monitor != nil {
+			monitors = append(monitors, monitor)
+		}
+	}
+	return monitors
+}
+
+// monitor builds the HealthMonitor configured by node, or nil if it is not
+// valid, along with its name.
+func (p *parser) monitor(node *yaml.Node) (libhealth.HealthMonitor, string) {
+	f := p.fields(node)
+	if f == nil {
+		return nil, ""
+	}
+	errs := len(p.errs)
+
+	var m monitor
+	m.name = f.str("name", true)
+	m.description = f.str("description", false)
+	m.docURL = f.str("docURL", false)
+	m.urgency = f.urgency("urgency")
+	m.options = f.durations()
+
+	kind, kindNode := f.scalar("type", true)
+	params := f.node("params", true)
+	f.unknown()
+
+	if kindNode != nil {
+		build, exists := builders[kind]
+		switch {
+		case !exists:
+			p.errorf(kindNode, "unknown type %q, must be one of http, tcp, exec or transitive", kind)
+		case params != nil:
+			if pf := p.fields(params); pf != nil {
+				m.build = build(pf)
+				pf.unknown()
+			}
+		}
+	}
+
+	if len(p.errs) > errs || m.build == nil {
+		return nil, m.name
+	}
+	return m.build(m), m.name
+}
+
+// monitor is the configuration of a HealthMonitor.
+type monitor struct {
+	name        string
+	description string
+	docURL      string
+	urgency     libhealth.Urgency
+	options     []libhealth.MonitorOption
+	build       func(m monitor) libhealth.HealthMonitor
+}
+
+// fields are the values of a YAML mapping by key, so that any keys which
+// are never looked up can be reported as unknown.
+type fields struct {
+	p      *parser
+	parent *yaml.Node
+	keys   map[string]*yaml.Node
+	values map[string]*yaml.Node
+	order  []string
+	used   map[string]bool
+}
+
+func (p *parser) fields(node *yaml.Node) *fields {
+	if node.Kind != yaml.MappingNode {
+		p.errorf(node, "expected a mapping")
+		return nil
+	}
+
+	f := &fields{
+		p:      p,
+		parent: node,
+		keys:   make(map[string]*yaml.Node),
+		values: make(map[string]*yaml.Node),
+		used:   make(map[string]bool),
+	}
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		key, value := node.Content[i], resolve(node.Content[i+1])
+		if _, exists := f.keys[key.Value]; exists {
+			p.errorf(key, "duplicate key %q", key.Value)
+			continue
+		}
+		f.keys[key.Value] = key
+		f.values[key.Value] = value
+		f.order = append(f.order, key.Value)
+	}
+	return f
+}
+
+// node returns the value of key, reporting it as missing if it is required.
+func (f *fields) node(key string, required bool) *yaml.Node {
+	f.used[key] = true
+	value, exists := f.values[key]
+	if !exists && required {
+		f.p.errorf(f.parent, "missing %s", key)
+	}
+	return value
+}
+
+// scalar returns the value of key and its node, or nil if it is missing or
+// not a scalar.
+func (f *fields) scalar(key string, required bool) (string, *yaml.Node) {
+	value := f.node(key, required)
+	if value == nil {
+		return "", nil
+	}
+	if value.Kind != yaml.ScalarNode {
+		f.p.errorf(value, "%s must be a single value", key)
+		return "", nil
+	}
+	if value.Value == "" && required {
+		f.p.errorf(value, "missing %s", key)
+		return "", nil
+	}
+	return value.Value, value
+}
+
+func (f *fields) str(key string, required bool) string {
+	value, _ := f.scalar(key, required)
+	return value
+}
+
+func (f *fields) urgency(key string) libhealth.Urgency {
+	value, node := f.scalar(key, true)
+	if node == nil {
+		return libhealth.UNKNOWN
+	}
+	urgency, err := libhealth.ParseUrgencyStrict(value)
+	if err != nil {
+		f.p.errorf(node, "%v", err)
+	}
+	return urgency
+}
+
+func (f *fields) duration(key string) (time.Duration, bool) {
+	value, node := f.scalar(key, false)
+	if node == nil {
+		return 0, false
+	}
+	d, err := time.ParseDuration(value)
+	switch {
+	case err != nil:
+		f.p.errorf(node, "invalid %s: %v", key, err)
+		return 0, false
+	case d <= 0:
+		f.p.errorf(node, "%s must be positive", key)
+		return 0, false
+	}
+	return d, true
+}
+
+// durations returns the options for the period and timeout, if configured.
+func (f *fields) durations() []libhealth.MonitorOption {
+	var options []libhealth.MonitorOption
+	if period, ok := f.duration("period"); ok {
+		options = append(options, libhealth.WithPeriod(period))
+	}
+	if timeout, ok := f.duration("timeout"); ok {
+		options = append(options, libhealth.WithTimeout(timeout))
+	}
+	return options
+}
+
+func (f *fields) list(key string, required bool) []string {
+	value := f.node(key, required)
+	if value == nil {
+		return nil
+	}
+	if value.Kind != yaml.SequenceNode {
+		f.p.errorf(value, "%s must be a list", key)
+		return nil
+	}
+	if len(value.Content) == 0 && required {
+		f.p.errorf(value, "%s must not be empty", key)
+		return nil
+	}
+
+	values := make([]string, 0, len(value.Content))
+	for _, item := range value.Content {
+		item = resolve(item)
+		if item.Kind != yaml.ScalarNode {
+			f.p.errorf(item, "%s must be a list of single values", key)
+			return nil
+		}
+		values = append(values, item.Value)
+	}
+	return values
+}
+
+// unknown reports the keys which were never looked up.
+func (f *fields) unknown() {
+	for _, key := range f.order {
+		if !f.used[key] {
+			f.p.errorf(f.keys[key], "unknown key %q", key)
+		}
+	}
+}
+
+// resolve follows node if it is an alias.
+func resolve(node *yaml.Node) *yaml.Node {
+	for node.Kind == yaml.AliasNode {
+		node = node.Alias
+	}
+	return node
+}
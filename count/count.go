@@ -5,8 +5,10 @@ import (
 	"fmt"
 	"strings"
 	"sync"
+	"time"
 
 	"oss.indeed.com/go/libhealth"
+	"oss.indeed.com/go/libhealth/clock"
 	"oss.indeed.com/go/libhealth/count/internal/data"
 )
 
@@ -68,15 +70,57 @@ var (
 	SizeOneHour        = BucketPeriod(data.OneHour)
 )
 
+// An Option configures optional behavior of a Countable.
+type Option func(c *container)
+
+// WithClock configures the Clock used to determine which bucket is
+// accumulating values, for a Countable created by IntsEvery or
+// FloatsEvery. A BucketPeriod determines the bucket by itself, so
+// Ints and Floats return an error if given WithClock. If not provided,
+// clock.System is used.
+func WithClock(c clock.Clock) Option {
+	return func(container *container) {
+		container.clock = c
+	}
+}
+
 type container struct {
 	lock       sync.RWMutex
 	varexp     string
 	length     int
 	thresholds []Threshold
+	clock      clock.Clock
 	buckets    *data.Buckets
 }
 
-func newContainer(varname string, size BucketPeriod, length int, zero data.Value) (*container, error) {
+func newContainer(varname string, size BucketPeriod, length int, zero data.Value, options []Option) (*container, error) {
+	c, err := configure(varname, length, options)
+	if err != nil {
+		return nil, err
+	}
+	if c.clock != nil {
+		return nil, fmt.Errorf("a counter with a BucketPeriod cannot use WithClock, varname: %s", varname)
+	}
+	c.buckets = data.NewBuckets(data.Ticker(size), length, zero)
+	return c, nil
+}
+
+func newClockedContainer(varname string, period time.Duration, length int, zero data.Value, options []Option) (*container, error) {
+	c, err := configure(varname, length, options)
+	if err != nil {
+		return nil, err
+	}
+	if period <= 0 {
+		return nil, fmt.Errorf("a counter must have a positive period, period: %s", period)
+	}
+	if c.clock == nil {
+		c.clock = clock.System
+	}
+	c.buckets = data.NewClockedBuckets(period, length, zero, c.clock)
+	return c, nil
+}
+
+func configure(varname string, length int, options []Option) (*container, error) {
 	if err := checkLength(length); err != nil {
 		return nil, err
 	}
@@ -85,9 +129,10 @@ func newContainer(varname string, size BucketPeriod, length int, zero data.Value
 		varexp:     varname,
 		length:     length,
 		thresholds: make([]Threshold, 0),
-		buckets:    data.NewBuckets(data.Ticker(size), length, zero),
 	}
-
+	for _, option := range options {
+		option(c)
+	}
 	return c, nil
 }
 
@@ -1,13 +1,23 @@
 package count
 
 import (
+	"time"
+
 	"oss.indeed.com/go/libhealth"
 	"oss.indeed.com/go/libhealth/count/internal/data"
 )
 
 // Floats will create a FloatCounter.
-func Floats(varname string, size BucketPeriod, length int) (FloatCounter, error) {
-	c, err := newContainer(varname, size, length, data.NewFloat(0))
+func Floats(varname string, size BucketPeriod, length int, options ...Option) (FloatCounter, error) {
+	c, err := newContainer(varname, size, length, data.NewFloat(0), options)
+	return (*floats)(c), err
+}
+
+// FloatsEvery will create a FloatCounter, where each bucket spans period.
+// Unlike Floats, the buckets can be rolled over by the Clock configured
+// WithClock.
+func FloatsEvery(varname string, period time.Duration, length int, options ...Option) (FloatCounter, error) {
+	c, err := newClockedContainer(varname, period, length, data.NewFloat(0), options)
 	return (*floats)(c), err
 }
 
@@ -8,6 +8,8 @@ package data
 import (
 	"bytes"
 	"time"
+
+	"oss.indeed.com/go/libtime"
 )
 
 const (
@@ -20,7 +22,8 @@ const (
 // Not threadsafe, bring your own lock.
 type Buckets struct {
 	buckets    []Value
-	ticker     Ticker
+	tick       func(now time.Time) int
+	clock      libtime.Clock
 	oldestIdx  int
 	oldestTick int
 }
@@ -28,11 +31,24 @@ type Buckets struct {
 // NewBuckets creates a new Buckets of size length, where each bucket contains
 // values for spanning the duration specified by period.
 func NewBuckets(period Ticker, length int, zero Value) *Buckets {
+	return newBuckets(func(time.Time) int { return period() }, length, zero, libtime.SystemClock())
+}
+
+// NewClockedBuckets creates a new Buckets of size length, where each bucket
+// contains values spanning period, as measured by clock.
+func NewClockedBuckets(period time.Duration, length int, zero Value, clock libtime.Clock) *Buckets {
+	return newBuckets(func(now time.Time) int {
+		return int(now.UnixNano() / int64(period))
+	}, length, zero, clock)
+}
+
+func newBuckets(t func(now time.Time) int, length int, zero Value, clock libtime.Clock) *Buckets {
 	return &Buckets{
 		buckets:    fill(zero, length),
-		ticker:     period,
+		tick:       t,
+		clock:      clock,
 		oldestIdx:  1,
-		oldestTick: period() - length + 1,
+		oldestTick: t(clock.Now()) - length + 1,
 	}
 }
 
@@ -46,7 +62,7 @@ func fill(v Value, length int) []Value {
 
 // Increment the current bucket by delta.
 func (bs *Buckets) Increment(delta Value) {
-	tick := bs.ticker()
+	tick := bs.tick(bs.clock.Now())
 
 	newestTick := bs.oldestTick + len(bs.buckets) - 1
 	if newestTick == tick {
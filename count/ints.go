@@ -1,13 +1,23 @@
 package count
 
 import (
+	"time"
+
 	"oss.indeed.com/go/libhealth"
 	"oss.indeed.com/go/libhealth/count/internal/data"
 )
 
 // Ints will create an IntCounter.
-func Ints(varname string, size BucketPeriod, length int) (IntCounter, error) {
-	c, err := newContainer(varname, size, length, data.NewInt(0))
+func Ints(varname string, size BucketPeriod, length int, options ...Option) (IntCounter, error) {
+	c, err := newContainer(varname, size, length, data.NewInt(0), options)
+	return (*ints)(c), err
+}
+
+// IntsEvery will create an IntCounter, where each bucket spans period.
+// Unlike Ints, the buckets can be rolled over by the Clock configured
+// WithClock.
+func IntsEvery(varname string, period time.Duration, length int, options ...Option) (IntCounter, error) {
+	c, err := newClockedContainer(varname, period, length, data.NewInt(0), options)
 	return (*ints)(c), err
 }
 
@@ -2,10 +2,12 @@ package count
 
 import (
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
 
 	"oss.indeed.com/go/libhealth"
+	"oss.indeed.com/go/libhealth/clock"
 )
 
 func makeTicker() (BucketPeriod, *int) {
@@ -269,3 +271,33 @@ func Test_Ints_One(t *testing.T) {
 	counter.Increment(11)
 	check(t, counter.Health(), libhealth.MAJOR, "max5")
 }
+
+func Test_Ints_WithClock(t *testing.T) {
+	fake := clock.NewFake(time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC))
+	counter, err := IntsEvery("test-ints-clock", time.Minute, 3, WithClock(fake))
+	require.NoError(t, err)
+
+	counter.Set(MaxIntThreshold{
+		Threshold:   5,
+		Description: "max5",
+		Severity:    libhealth.MAJOR,
+	})
+
+	counter.Increment(6)
+	check(t, counter.Health(), libhealth.OK, "ok")
+
+	// the bucket of the increment is completed
+	fake.Advance(time.Minute)
+	check(t, counter.Health(), libhealth.MAJOR, "max5")
+
+	fake.Advance(time.Minute)
+	check(t, counter.Health(), libhealth.OK, "ok")
+}
+
+func Test_Ints_WithClock_BucketPeriod(t *testing.T) {
+	_, err := Ints("test-ints-clock", SizeOneMinute, 3, WithClock(clock.System))
+	require.Error(t, err)
+
+	_, err = IntsEvery("test-ints-clock", 0, 3)
+	require.Error(t, err)
+}
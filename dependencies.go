@@ -2,10 +2,19 @@ package libhealth
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"runtime/debug"
 	"sync"
 	"time"
+
+	"oss.indeed.com/go/libhealth/clock"
 )
 
+// ErrUnknownMonitor is returned when there is no HealthMonitor registered
+// with a given name.
+var ErrUnknownMonitor = errors.New("no health monitor registered with name")
+
 // BasicDependencySet is the standard implementation of DependancySet that
 // behaves the way you would expect. HealthMonitors are registered to a
 // DependencySet instance, and then you can call Live() or Background() on
@@ -13,13 +22,31 @@ import (
 // Check() methods, whereas Background() will retrieve the cached Health
 // of that health check.
 type BasicDependencySet struct {
-	monitors map[string]HealthMonitor
+	monitors map[string]*registration
 	cached   map[string]Result
 	lock     sync.RWMutex // locks the map structure, but not the values
 
-	ctx context.Context
+	scheduler       Scheduler
+	liveMinInterval time.Duration
+	historySize     int
+	clock           clock.Clock
+	policy          AggregationPolicy
+
+	ctx    context.Context
+	cancel context.CancelFunc
+	stop   chan struct{} // closed by Stop to end the background checks
+	closed bool          // guarded by lock
 
+	running      sync.WaitGroup // background goroutines and in-flight checks
 	initialRunWg sync.WaitGroup
+
+	overrides map[string]Override    // guarded by lock
+	expiries  map[string]clock.Timer // of overrides, guarded by lock
+	draining  bool                   // guarded by lock
+
+	bus     bus
+	overall Status        // guarded by lock, as of the latest published Event
+	changed chan struct{} // guarded by lock, closed and replaced on every change
 }
 
 // NewBasicDependencySet will create a new BasicDependencySet instance and
@@ -30,13 +57,39 @@ func NewBasicDependencySet(monitors ...HealthMonitor) *BasicDependencySet {
 
 // NewBasicDependencySet will create a new BasicDependencySet instance using
 // a specific context and register all of the provided HealthMonitor instances.
+// Cancelling ctx stops the background checks, the same as calling Stop.
 func NewBasicDependencySetWithContext(ctx context.Context, monitors ...HealthMonitor) *BasicDependencySet {
+	deps := NewBasicDependencySetWithOptions(ctx)
+	deps.Register(monitors...)
+	return deps
+}
+
+// NewBasicDependencySetWithOptions will create a new BasicDependencySet
+// instance using a specific context, and configure optional behavior based
+// on the provided options. HealthMonitors are added with Register.
+// Cancelling ctx stops the background checks, the same as calling Stop.
+func NewBasicDependencySetWithOptions(ctx context.Context, options ...DependencySetOption) *BasicDependencySet {
+	ctx, cancel := context.WithCancel(ctx)
 	deps := &BasicDependencySet{
-		monitors: make(map[string]HealthMonitor),
-		cached:   make(map[string]Result),
-		ctx:      ctx,
+		monitors:  make(map[string]*registration),
+		cached:    make(map[string]Result),
+		overrides: make(map[string]Override),
+		expiries:  make(map[string]clock.Timer),
+		scheduler: Fixed,
+		clock:     clock.System,
+		policy:    HCv3,
+		ctx:       ctx,
+		cancel:    cancel,
+		stop:      make(chan struct{}),
+		overall:   OK,
+		changed:   make(chan struct{}),
 	}
-	deps.Register(monitors...)
+
+	for _, option := range options {
+		option(deps)
+	}
+	deps.bus.clock = deps.clock
+
 	return deps
 }
 
@@ -45,115 +98,344 @@ func NewBasicDependencySetWithContext(ctx context.Context, monitors ...HealthMon
 // Register(). They will only be executed on calls to Live(). This is important,
 // because it means such a checker will be set to OUTAGE if only
 // Background() is ever called.
+//
+// Registering a HealthMonitor with the same name as one which is already
+// registered replaces it, as with Replace.
+//
+// Once the BasicDependencySet has been stopped, Register does nothing.
 func (d *BasicDependencySet) Register(monitors ...HealthMonitor) {
+	d.lock.Lock()
+	defer d.lock.Unlock()
+
+	if d.isClosed() {
+		return
+	}
+
 	for _, monitor := range monitors {
-		notyetrun := fresh(monitor)
+		d.unregister(monitor.Name())
+
+		reg := &registration{
+			monitor:    monitor,
+			stop:       make(chan struct{}),
+			registered: d.clock.Now(),
+			history:    newHistory(d.historySize, d.clock.Now()),
+		}
 
 		// set status not-run-yet
-		d.update(monitor, &notyetrun)
+		d.monitors[monitor.Name()] = reg
+		d.cached[monitor.Name()] = fresh(monitor, d.clock.Now())
 
 		d.initialRunWg.Add(1)
-		firstTime := func(monitor HealthMonitor) {
-			d.run(monitor, time.Now())
-			d.initialRunWg.Done()
+		d.running.Add(1)
+		go d.schedule(reg)
+	}
+	d.publishTransitions(nil, nil)
+}
+
+// Replace will stop the HealthMonitor registered with the same name as
+// monitor and register monitor in its place. The cached Result of the old
+// HealthMonitor is discarded, and nothing it reports afterwards is kept.
+func (d *BasicDependencySet) Replace(monitor HealthMonitor) {
+	d.Register(monitor)
+}
+
+// Unregister will stop and remove the HealthMonitors with the given names,
+// along with their cached Results and Overrides. Names which are not
+// registered are ignored.
+func (d *BasicDependencySet) Unregister(names ...string) {
+	d.lock.Lock()
+	defer d.lock.Unlock()
+
+	for _, name := range names {
+		d.unregister(name)
+		d.clearOverride(name)
+	}
+	d.publishTransitions(nil, nil)
+}
+
+// unregister must be called while holding lock.
+func (d *BasicDependencySet) unregister(name string) {
+	reg, exists := d.monitors[name]
+	if !exists {
+		return
+	}
+	close(reg.stop)
+	delete(d.monitors, name)
+	delete(d.cached, name)
+}
+
+// registration is a HealthMonitor as registered to a BasicDependencySet.
+// A HealthMonitor which is replaced gets a new registration, so that the
+// old one can be told apart.
+type registration struct {
+	monitor    HealthMonitor
+	stop       chan struct{} // closed when unregistered
+	registered time.Time
+
+	// guarded by the lock of the BasicDependencySet
+	panics  int
+	checked time.Time // when the latest check finished
+	flight  *flight   // the check in progress, if any
+	history *history  // nil unless configured by WithHistory
+	settled bool      // whether a check has been OK or the grace period is over
+}
+
+// flight is a check in progress, which anyone else who wants a
+// check of the same registration waits for instead of starting another.
+type flight struct {
+	done    chan struct{}
+	result  Result
+	waiters int // guarded by the lock of the BasicDependencySet
+}
+
+// schedule runs the checks of reg when its Scheduler says so, until it is
+// unregistered or the BasicDependencySet is stopped.
+func (d *BasicDependencySet) schedule(reg *registration) {
+	defer d.running.Done()
+
+	scheduler := d.schedulerOf(reg.monitor)
+	if !d.wait(reg, scheduler.Initial(reg.monitor)) {
+		d.initialRunWg.Done()
+		return
+	}
+
+	start := d.clock.Now()
+	result := d.run(reg, start)
+	d.initialRunWg.Done()
+
+	if reg.monitor.Period() <= 0 {
+		return
+	}
+
+	// each healthcheck updates its associated health when scheduled
+	// if the check times out, the health is set to outage
+	for d.wait(reg, scheduler.Next(reg.monitor, result.Health)-d.clock.Since(start)) {
+		start = d.clock.Now()
+		result = d.run(reg, start)
+	}
+}
+
+// wait returns true after delay, or false if reg is stopped first.
+func (d *BasicDependencySet) wait(reg *registration, delay time.Duration) bool {
+	timer := d.clock.NewTimer(delay)
+	defer timer.Stop()
+
+	select {
+	case <-reg.stop:
+		return false
+	case <-d.stop:
+		return false
+	case <-d.ctx.Done():
+		return false
+	default:
+	}
+
+	select {
+	case <-timer.C():
+		return true
+	case <-reg.stop:
+		return false
+	case <-d.stop:
+		return false
+	case <-d.ctx.Done():
+		return false
+	}
+}
+
+func (d *BasicDependencySet) schedulerOf(monitor HealthMonitor) Scheduler {
+	if scheduled, ok := monitor.(interface{ Scheduler() Scheduler }); ok {
+		if scheduler := scheduled.Scheduler(); scheduler != nil {
+			return scheduler
 		}
+	}
+	return d.scheduler
+}
 
-		// then run the check every period
-		if monitor.Period() > 0 {
-			go func(monitor HealthMonitor) {
-				// immediately run a check, then schedule more
-				firstTime(monitor)
-
-				// each healthcheck ticks and updates its associated health
-				// if the check times out, the health is set to outage
-				ticker := time.NewTicker(monitor.Period())
-				defer ticker.Stop()
-
-				for now := range ticker.C {
-					d.run(monitor, now)
-				}
-			}(monitor)
-		} else {
-			// then immediately kick off a check
-			go firstTime(monitor)
+// Stop ends the background checks of every registered HealthMonitor and
+// waits for any checks which are in-flight to complete. If ctx expires
+// first, the in-flight checks are cancelled and ctx.Err() is returned.
+// After Stop, calls to Register are ignored and Live reports the
+// cached results, the same as Background.
+func (d *BasicDependencySet) Stop(ctx context.Context) error {
+	d.lock.Lock()
+	if !d.closed {
+		d.closed = true
+		close(d.stop)
+		for _, timer := range d.expiries {
+			timer.Stop()
 		}
 	}
+	d.lock.Unlock()
+
+	done := make(chan struct{})
+	go func() {
+		d.running.Wait()
+		close(done)
+	}()
+
+	// either way nothing is left running that needs the context
+	defer d.cancel()
+
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// Close is the same as Stop, but waits for in-flight checks without a deadline.
+func (d *BasicDependencySet) Close() error {
+	return d.Stop(context.Background())
+}
+
+// isClosed must be called while holding lock.
+func (d *BasicDependencySet) isClosed() bool {
+	return d.closed || d.ctx.Err() != nil
 }
 
 func (d *BasicDependencySet) waitUntilInitialRun() {
 	d.initialRunWg.Wait()
 }
 
-func (d *BasicDependencySet) run(monitor HealthMonitor, now time.Time) Result {
-	result := performCheck(d.ctx, monitor, now)
-	d.update(monitor, &result)
-	return result
+// run checks reg, or waits for the result of the check of reg which
+// is already in progress.
+func (d *BasicDependencySet) run(reg *registration, now time.Time) Result {
+	d.lock.Lock()
+	if f := reg.flight; f != nil {
+		f.waiters++
+		d.lock.Unlock()
+		<-f.done
+		return f.result
+	}
+	f := &flight{done: make(chan struct{})}
+	reg.flight = f
+	d.lock.Unlock()
+
+	f.result = performCheck(d.ctx, d.clock, reg.monitor, now)
+	d.update(reg, &f.result)
+	close(f.done)
+	return f.result
 }
 
-func performCheck(ctx context.Context, monitor HealthMonitor, startTime time.Time) Result {
-	ctx, cancelFunc := context.WithDeadline(ctx, startTime.Add(monitor.Timeout()))
+// live is like run, but returns the cached Result of reg instead if it
+// was checked within the minimum live interval.
+func (d *BasicDependencySet) live(reg *registration, now time.Time) Result {
+	d.lock.RLock()
+	name := reg.monitor.Name()
+	if d.liveMinInterval > 0 && d.monitors[name] == reg && now.Sub(reg.checked) < d.liveMinInterval {
+		result := d.cached[name]
+		d.lock.RUnlock()
+		return result
+	}
+	d.lock.RUnlock()
+
+	return d.run(reg, now)
+}
+
+func performCheck(ctx context.Context, c clock.Clock, monitor HealthMonitor, startTime time.Time) Result {
+	ctx, cancelFunc := clock.WithDeadline(clock.NewContext(ctx, c), c, startTime.Add(monitor.Timeout()))
 	defer cancelFunc()
 
 	select {
-	case health := <-asyncCheck(ctx, monitor):
-		return wrap(monitor, health)
+	case result := <-asyncCheck(ctx, c, monitor, startTime):
+		return result
 	case <-ctx.Done():
 		return timeout(monitor, startTime)
 	}
 }
 
-func asyncCheck(ctx context.Context, monitor HealthMonitor) <-chan Health {
-	healthc := make(chan Health, 1)
+// asyncCheck runs the check of monitor, recovering from a panic by
+// reporting an OUTAGE instead.
+func asyncCheck(ctx context.Context, c clock.Clock, monitor HealthMonitor, startTime time.Time) <-chan Result {
+	resultc := make(chan Result, 1)
 	go func() {
-		healthc <- monitor.Check(ctx)
+		defer func() {
+			if value := recover(); value != nil {
+				resultc <- panicked(monitor, startTime, c.Now(), value, debug.Stack())
+			}
+		}()
+		resultc <- wrap(monitor, monitor.Check(ctx))
 	}()
 
-	return healthc
+	return resultc
 }
 
-func fresh(m HealthMonitor) Result {
-	h := NewHealth(OUTAGE, "healthcheck has not run yet")
+func fresh(m HealthMonitor, t time.Time) Result {
+	h := NewHealthAt(OUTAGE, "healthcheck has not run yet", t)
 	h.Urgency = m.Urgency()
-	h.Time = time.Now()
-	return wrap(m, h)
+	result := wrap(m, h)
+	result.initial = true
+	result.pending = true
+	return result
 }
 
 func timeout(m HealthMonitor, t time.Time) Result {
-	h := NewHealth(OUTAGE, "healthcheck timed out")
+	h := NewHealthAt(OUTAGE, "healthcheck timed out", t)
 	h.Urgency = m.Urgency()
-	h.Time = t
 	return wrap(m, h)
 }
 
+func panicked(m HealthMonitor, t, now time.Time, value interface{}, stack []byte) Result {
+	h := NewHealthAt(OUTAGE, fmt.Sprintf("healthcheck panicked: %v", value), t)
+	h.Urgency = m.Urgency()
+	h.Duration = now.Sub(t)
+	result := wrap(m, h)
+	result.stack = string(stack)
+	return result
+}
+
 func wrap(m HealthMonitor, h Health) Result {
 	// We don't care about the real state, just the downgraded one.
 	h.Status = h.Urgency.DowngradeWith(OK, h.Status)
-	return Result{
-		h,
-		m.Documentation(),
-		m.Description(),
-		m.LastOk(),
-		m.Period(),
-		m.Name(),
+	if h.observed != nil {
+		observed := *h.observed
+		observed.Status = observed.Urgency.DowngradeWith(OK, observed.Status)
+		h.observed = &observed
 	}
+	result := Result{
+		Health:   h,
+		docurl:   m.Documentation(),
+		desc:     m.Description(),
+		lastGood: m.LastOk(),
+		period:   m.Period(),
+		name:     m.Name(),
+	}
+	if child, ok := m.(interface{ Parents() []string }); ok {
+		result.parents = child.Parents()
+	}
+	if tagged, ok := m.(interface{ Tags() []string }); ok {
+		result.tags = tagged.Tags()
+	}
+	return result
 }
 
 // Background will retrieve the cached Health for each of the registered
 // HealthChecker instances.
 func (d *BasicDependencySet) Background() Summary {
-	return NewSummary(time.Now(), d.snapshotResults())
+	d.lock.RLock()
+	defer d.lock.RUnlock()
+
+	return d.summary(d.results())
 }
 
 // Live will force all of the HealthChecker instances to execute their
-// Check methods, and will update all cached Health as well.
+// Check methods, and will update all cached Health as well. Concurrent
+// calls share the checks in progress rather than each running their own,
+// and checks which ran within the interval configured by WithLiveMinInterval
+// are not run again.
 func (d *BasicDependencySet) Live() Summary {
-	monitors := d.snapshotMonitors()
+	monitors, ok := d.acquireMonitors()
+	if !ok {
+		return d.Background()
+	}
 
 	checkResults := make(chan Result)
-	start := time.Now()
+	start := d.clock.Now()
 	for _, monitor := range monitors {
-		go func(m HealthMonitor) {
-			checkResults <- d.run(m, start)
+		go func(reg *registration) {
+			defer d.running.Done()
+			checkResults <- d.live(reg, start)
 		}(monitor)
 	}
 
@@ -161,36 +443,190 @@ func (d *BasicDependencySet) Live() Summary {
 	for range monitors {
 		results = append(results, <-checkResults)
 	}
-	return NewSummary(time.Now(), results)
+
+	d.lock.RLock()
+	defer d.lock.RUnlock()
+
+	return d.summary(d.overridden(results))
+}
+
+// summary must be called while holding lock.
+func (d *BasicDependencySet) summary(results []Result) Summary {
+	summary := NewSummary(d.clock.Now(), results)
+	summary.draining = d.draining
+	summary.policy = d.policy
+	return summary
 }
 
-func (d *BasicDependencySet) update(monitor HealthMonitor, result *Result) {
+// Trigger will force the HealthMonitor registered with name to execute its
+// Check method, and will update its cached Health as well. If a check of
+// the HealthMonitor is already in progress, its Result is used instead.
+// If ctx expires before the check completes, ctx.Err() is returned, and
+// the check still updates the cached Health when it completes. The Result
+// is blocked by the failing parents of the HealthMonitor, the same as in
+// a Summary of d.
+func (d *BasicDependencySet) Trigger(ctx context.Context, name string) (Result, error) {
+	d.lock.RLock()
+	reg, exists := d.monitors[name]
+	closed := d.isClosed()
+	var cached Result
+	if exists && closed {
+		cached = d.suppressed(d.cached[name])
+	}
+	if exists && !closed {
+		d.running.Add(1)
+	}
+	d.lock.RUnlock()
+
+	switch {
+	case !exists:
+		return Result{}, fmt.Errorf("%w %q", ErrUnknownMonitor, name)
+	case closed:
+		return cached, nil
+	}
+
+	resultc := make(chan Result, 1)
+	go func() {
+		defer d.running.Done()
+		resultc <- d.run(reg, d.clock.Now())
+	}()
+
+	select {
+	case result := <-resultc:
+		d.lock.RLock()
+		defer d.lock.RUnlock()
+		return d.suppressed(result), nil
+	case <-ctx.Done():
+		return Result{}, ctx.Err()
+	}
+}
+
+// suppressed overrides result and marks it as blocked by its failing parents
+// among the results of d.
+//
+// Must be called while holding lock.
+func (d *BasicDependencySet) suppressed(result Result) Result {
+	result = d.overridden([]Result{result})[0]
+	results := []Result{result}
+	for _, cached := range d.results() {
+		if cached.name != result.name {
+			results = append(results, cached)
+		}
+	}
+	return suppress(results)[0]
+}
+
+// update caches result, unless reg has since been unregistered or replaced.
+// Once d is closed, the check may have been cancelled along with d, so result
+// is replaced by the cached Result instead.
+func (d *BasicDependencySet) update(reg *registration, result *Result) {
 	d.lock.Lock()
 	defer d.lock.Unlock()
-	d.monitors[monitor.Name()] = monitor
-	d.cached[monitor.Name()] = *result
+
+	reg.flight = nil
+	name := reg.monitor.Name()
+	if d.monitors[name] != reg {
+		return
+	}
+	if d.isClosed() {
+		*result = d.cached[name]
+		return
+	}
+
+	if result.stack != "" {
+		reg.panics++
+	}
+	result.panics = reg.panics
+	reg.checked = d.clock.Now()
+	result.pending = !d.settle(reg, result)
+	prev := d.cached[name]
+	reg.history.record(prev.settled(), result)
+
+	d.cached[name] = *result
+	d.publishTransitions(&prev, result)
 }
 
-func (d *BasicDependencySet) snapshotMonitors() []HealthMonitor {
+// settle reports whether result of reg counts, which it does unless it is not
+// OK during the grace period of the HealthMonitor, before any check has been OK.
+func (d *BasicDependencySet) settle(reg *registration, result *Result) bool {
+	if reg.settled {
+		return true
+	}
+	var grace time.Duration
+	if graceful, ok := reg.monitor.(interface{ GracePeriod() time.Duration }); ok {
+		grace = graceful.GracePeriod()
+	}
+	if result.SameAs(OK) || reg.checked.Sub(reg.registered) >= grace {
+		reg.settled = true
+	}
+	return reg.settled
+}
+
+// History returns the History of the HealthMonitor registered with name,
+// or false if there is no such HealthMonitor. The History is empty unless
+// configured by WithHistory.
+func (d *BasicDependencySet) History(name string) (History, bool) {
 	d.lock.RLock()
 	defer d.lock.RUnlock()
 
-	monitors := make([]HealthMonitor, 0, len(d.monitors))
-	for _, monitor := range d.monitors {
-		monitors = append(monitors, monitor)
+	reg, exists := d.monitors[name]
+	if !exists {
+		return History{}, false
+	}
+	return reg.history.snapshot(), true
+}
+
+// Histories returns the History of every registered HealthMonitor.
+func (d *BasicDependencySet) Histories() map[string]History {
+	d.lock.RLock()
+	defer d.lock.RUnlock()
+
+	histories := make(map[string]History, len(d.monitors))
+	for name, reg := range d.monitors {
+		histories[name] = reg.history.snapshot()
 	}
+	return histories
+}
+
+// Panics returns the number of times the check of each registered
+// HealthMonitor has panicked since it was registered.
+func (d *BasicDependencySet) Panics() map[string]int {
+	d.lock.RLock()
+	defer d.lock.RUnlock()
 
-	return monitors
+	panics := make(map[string]int, len(d.monitors))
+	for name, reg := range d.monitors {
+		panics[name] = reg.panics
+	}
+	return panics
 }
 
-func (d *BasicDependencySet) snapshotResults() []Result {
+// acquireMonitors returns a snapshot of the registered monitors, and
+// marks one check of each as running. If the set is closed nothing is
+// acquired and false is returned.
+func (d *BasicDependencySet) acquireMonitors() ([]*registration, bool) {
 	d.lock.RLock()
 	defer d.lock.RUnlock()
 
+	if d.isClosed() {
+		return nil, false
+	}
+
+	monitors := make([]*registration, 0, len(d.monitors))
+	for _, monitor := range d.monitors {
+		monitors = append(monitors, monitor)
+	}
+	d.running.Add(len(monitors))
+
+	return monitors, true
+}
+
+// results must be called while holding lock.
+func (d *BasicDependencySet) results() []Result {
 	results := make([]Result, 0, len(d.cached))
 	for _, result := range d.cached {
 		results = append(results, result)
 	}
 
-	return results
+	return d.overridden(results)
 }
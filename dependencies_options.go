@@ -0,0 +1,51 @@
+package libhealth
+
+import (
+	"time"
+
+	"oss.indeed.com/go/libhealth/clock"
+)
+
+// DependencySetOption configures optional behavior of a BasicDependencySet.
+type DependencySetOption func(set *BasicDependencySet)
+
+// WithDefaultScheduler configures the Scheduler used for the background checks of HealthMonitors which do not
+// provide their own. If not provided, Fixed is used.
+func WithDefaultScheduler(scheduler Scheduler) DependencySetOption {
+	return func(set *BasicDependencySet) {
+		set.scheduler = scheduler
+	}
+}
+
+// WithLiveMinInterval configures how recently a HealthMonitor must have been checked for Live to report its cached
+// Result instead of checking it again. If not provided, Live always checks every HealthMonitor.
+func WithLiveMinInterval(interval time.Duration) DependencySetOption {
+	return func(set *BasicDependencySet) {
+		set.liveMinInterval = interval
+	}
+}
+
+// WithHistory configures how many of the most recent results and status transitions of each HealthMonitor are kept
+// in its History. If not provided, no History is kept.
+func WithHistory(size int) DependencySetOption {
+	return func(set *BasicDependencySet) {
+		set.historySize = size
+	}
+}
+
+// WithSetClock configures the Clock used to schedule, time out and timestamp the checks of HealthMonitors, to expire
+// Overrides, and to debounce Subscriptions. HealthMonitors time their own checks, as configured by WithClock. The
+// Clock is also carried by the context of each check; see clock.FromContext. If not provided, clock.System is used.
+func WithSetClock(c clock.Clock) DependencySetOption {
+	return func(set *BasicDependencySet) {
+		set.clock = c
+	}
+}
+
+// WithAggregationPolicy configures how the Results of HealthMonitors are combined into the Overall Status of a
+// Summary. If not provided, HCv3 is used.
+func WithAggregationPolicy(policy AggregationPolicy) DependencySetOption {
+	return func(set *BasicDependencySet) {
+		set.policy = policy
+	}
+}
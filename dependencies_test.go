@@ -0,0 +1,223 @@
+package libhealth
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func countingMonitor(name string, count *int32, options ...MonitorOption) *Monitor {
+	return NewMonitorWithOptions(name, "", "", REQUIRED, func(ctx context.Context) Health {
+		atomic.AddInt32(count, 1)
+		return NewHealth(OK, "okay")
+	}, options...)
+}
+
+func TestBasicDependencySet_Close(t *testing.T) {
+	var count int32
+	deps := NewBasicDependencySet(countingMonitor("counting", &count, WithPeriod(5*time.Millisecond)))
+	deps.waitUntilInitialRun()
+
+	require.NoError(t, deps.Close())
+	stopped := atomic.LoadInt32(&count)
+
+	time.Sleep(25 * time.Millisecond)
+	require.Equal(t, stopped, atomic.LoadInt32(&count))
+
+	// the set keeps reporting the last known results
+	require.Equal(t, OK, deps.Live().Overall())
+	require.Equal(t, stopped, atomic.LoadInt32(&count))
+}
+
+func TestBasicDependencySet_Stop_inFlight(t *testing.T) {
+	started := make(chan struct{}, 1)
+	release := make(chan struct{})
+	defer close(release)
+	checks := sequenceChecker(OK, OK)
+	deps := NewBasicDependencySet(NewMonitorWithOptions("slow", "", "", REQUIRED, func(ctx context.Context) Health {
+		select {
+		case started <- struct{}{}:
+		default:
+		}
+		<-release
+		return checks(ctx)
+	}, WithPeriod(0)))
+	<-started
+	release <- struct{}{}
+	deps.waitUntilInitialRun()
+	require.Equal(t, OK, deps.Background().Overall())
+
+	// a check cancelled by stopping the set is not reported as a timeout
+	go deps.Live()
+	<-started
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	require.Error(t, deps.Stop(ctx))
+
+	require.Eventually(t, func() bool {
+		deps.lock.RLock()
+		defer deps.lock.RUnlock()
+		return deps.monitors["slow"].flight == nil
+	}, time.Second, time.Millisecond)
+	require.Equal(t, OK, deps.Background().Overall())
+	require.Equal(t, OK, deps.Live().Overall())
+}
+
+func TestBasicDependencySet_Register_afterClose(t *testing.T) {
+	deps := NewBasicDependencySet()
+	require.NoError(t, deps.Close())
+
+	var count int32
+	deps.Register(countingMonitor("counting", &count))
+	deps.waitUntilInitialRun()
+
+	require.Empty(t, deps.Background().results)
+	require.Zero(t, atomic.LoadInt32(&count))
+}
+
+func TestBasicDependencySet_contextCancelled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	var count int32
+	deps := NewBasicDependencySetWithContext(ctx, countingMonitor("counting", &count, WithPeriod(5*time.Millisecond)))
+	deps.waitUntilInitialRun()
+
+	cancel()
+	require.NoError(t, deps.Close())
+	stopped := atomic.LoadInt32(&count)
+
+	time.Sleep(25 * time.Millisecond)
+	require.Equal(t, stopped, atomic.LoadInt32(&count))
+}
+
+func TestBasicDependencySet_Stop_deadline(t *testing.T) {
+	started := make(chan struct{})
+	release := make(chan struct{})
+	defer close(release)
+
+	deps := NewBasicDependencySet(NewMonitor("blocking", "", "", REQUIRED, func(ctx context.Context) Health {
+		close(started)
+		<-release
+		return NewHealth(OK, "okay")
+	}, nil))
+	<-started
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	require.Equal(t, context.DeadlineExceeded, deps.Stop(ctx))
+}
+
+func TestBasicDependencySet_Unregister(t *testing.T) {
+	var count int32
+	deps := NewBasicDependencySet(
+		countingMonitor("counting", &count, WithPeriod(5*time.Millisecond)),
+		countingMonitor("other", new(int32)),
+	)
+	deps.waitUntilInitialRun()
+
+	deps.Unregister("counting", "missing")
+	time.Sleep(10 * time.Millisecond) // let a tick already under way finish
+	stopped := atomic.LoadInt32(&count)
+
+	results := deps.Background().results
+	require.Len(t, results, 1)
+	require.Equal(t, "other", results[0].name)
+
+	time.Sleep(25 * time.Millisecond)
+	require.Equal(t, stopped, atomic.LoadInt32(&count))
+	require.Len(t, deps.Live().results, 1)
+}
+
+func TestBasicDependencySet_Replace(t *testing.T) {
+	var oldCount int32
+	deps := NewBasicDependencySet(countingMonitor("counting", &oldCount, WithPeriod(5*time.Millisecond)))
+	deps.waitUntilInitialRun()
+
+	deps.Replace(NewMonitor("counting", "replacement", "", REQUIRED, func(ctx context.Context) Health {
+		return NewHealth(OUTAGE, "replaced")
+	}, nil))
+	deps.waitUntilInitialRun()
+	time.Sleep(10 * time.Millisecond) // let a tick already under way finish
+	stopped := atomic.LoadInt32(&oldCount)
+
+	time.Sleep(25 * time.Millisecond)
+	require.Equal(t, stopped, atomic.LoadInt32(&oldCount))
+
+	results := deps.Background().results
+	require.Len(t, results, 1)
+	require.Equal(t, "replacement", results[0].desc)
+	require.Equal(t, OUTAGE, results[0].Status)
+}
+
+func TestBasicDependencySet_panic(t *testing.T) {
+	deps := NewBasicDependencySet(NewMonitor("panicking", "", "", WEAK, func(ctx context.Context) Health {
+		panic("oh no")
+	}, nil))
+	defer deps.Close()
+	deps.waitUntilInitialRun()
+
+	results := deps.Live().results
+	require.Len(t, results, 1)
+	require.Equal(t, MINOR, results[0].Status)
+	require.Equal(t, Message("healthcheck panicked: oh no"), results[0].Message)
+	require.Contains(t, results[0].stack, "panic")
+	require.Equal(t, 2, results[0].panics)
+	require.Equal(t, map[string]int{"panicking": 2}, deps.Panics())
+
+	components := copyComponents(deps.Background())
+	require.Equal(t, 2, components[0].Panics)
+	require.NotEmpty(t, components[0].PanicStack)
+}
+
+func TestBasicDependencySet_Live_concurrent(t *testing.T) {
+	var count int32
+	release := make(chan struct{})
+	deps := NewBasicDependencySet(NewMonitor("blocking", "", "", REQUIRED, func(ctx context.Context) Health {
+		if atomic.AddInt32(&count, 1) > 1 {
+			<-release
+		}
+		return NewHealth(OK, "okay")
+	}, nil))
+	defer deps.Close()
+	deps.waitUntilInitialRun()
+
+	var wg sync.WaitGroup
+	for i := 0; i < 3; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			require.Equal(t, OK, deps.Live().Overall())
+		}()
+	}
+	waitForWaiters(t, deps, "blocking", 2)
+	close(release)
+	wg.Wait()
+
+	require.Equal(t, int32(2), atomic.LoadInt32(&count))
+}
+
+// waitForWaiters waits until n callers are waiting for the check of name
+// which is in progress.
+func waitForWaiters(t *testing.T, deps *BasicDependencySet, name string, n int) {
+	require.Eventually(t, func() bool {
+		deps.lock.RLock()
+		defer deps.lock.RUnlock()
+		f := deps.monitors[name].flight
+		return f != nil && f.waiters == n
+	}, time.Second, time.Millisecond)
+}
+
+func TestBasicDependencySet_WithLiveMinInterval(t *testing.T) {
+	var count int32
+	deps := NewBasicDependencySetWithOptions(context.Background(), WithLiveMinInterval(time.Hour))
+	defer deps.Close()
+	deps.Register(countingMonitor("counting", &count))
+	deps.waitUntilInitialRun()
+
+	require.Equal(t, OK, deps.Live().Overall())
+	require.Equal(t, int32(1), atomic.LoadInt32(&count))
+}
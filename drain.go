@@ -0,0 +1,98 @@
+package libhealth
+
+import (
+	"context"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+)
+
+// Draining is the condition reported by the healthcheck endpoints while the
+// DependencySet is draining.
+const Draining = "DRAINING"
+
+// A Drainer is a DependencySet which can be drained, so that load balancers
+// stop sending traffic before the application shuts down.
+type Drainer interface {
+	Drain()
+}
+
+var _ Drainer = (*BasicDependencySet)(nil)
+
+// Drain will make every Summary of d report that it is draining, so that the
+// healthcheck endpoints respond with HTTP 503 service unavailable and the
+// condition DRAINING, no matter the results of the HealthMonitors. Draining
+// cannot be undone.
+func (d *BasicDependencySet) Drain() {
+	d.lock.Lock()
+	defer d.lock.Unlock()
+
+	d.draining = true
+}
+
+// Draining returns whether Drain has been called.
+func (d *BasicDependencySet) Draining() bool {
+	d.lock.RLock()
+	defer d.lock.RUnlock()
+
+	return d.draining
+}
+
+// DrainAndShutdown waits for one of signals, or SIGTERM if none are given.
+// Then it drains set, waits for period so that load balancers notice, and
+// gracefully shuts down server, returning the error of Shutdown. Connections
+// which are not closed within timeout of the shutdown are closed forcibly.
+// If ctx is done before a signal arrives, ctx.Err() is returned instead and
+// nothing is drained or shut down; if it is done during period, server is
+// shut down right away.
+func DrainAndShutdown(
+	ctx context.Context,
+	server *http.Server,
+	set Drainer,
+	period time.Duration,
+	timeout time.Duration,
+	signals ...os.Signal,
+) error {
+	if len(signals) == 0 {
+		signals = []os.Signal{syscall.SIGTERM}
+	}
+
+	received := make(chan os.Signal, 1)
+	signal.Notify(received, signals...)
+	defer signal.Stop(received)
+
+	return drainAndShutdown(ctx, server, set, period, timeout, received)
+}
+
+func drainAndShutdown(
+	ctx context.Context,
+	server *http.Server,
+	set Drainer,
+	period time.Duration,
+	timeout time.Duration,
+	received <-chan os.Signal,
+) error {
+	select {
+	case <-received:
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+
+	set.Drain()
+	timer := time.NewTimer(period)
+	select {
+	case <-timer.C:
+	case <-ctx.Done():
+		timer.Stop()
+	}
+
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+	if err := server.Shutdown(shutdownCtx); err != nil {
+		_ = server.Close()
+		return err
+	}
+	return nil
+}
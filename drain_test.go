@@ -0,0 +1,110 @@
+package libhealth
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestBasicDependencySet_Drain(t *testing.T) {
+	deps := NewBasicDependencySet(NewMonitorWithOptions("healthy", "", "", REQUIRED, sequenceChecker(OK, OK)))
+	defer deps.Close()
+	deps.waitUntilInitialRun()
+
+	require.False(t, deps.Draining())
+	deps.Drain()
+	require.True(t, deps.Draining())
+
+	for _, summary := range []Summary{deps.Background(), deps.Live()} {
+		require.True(t, summary.Draining())
+		require.Equal(t, OK, summary.Overall())
+		require.Equal(t, http.StatusServiceUnavailable, ComputeStatusCode(true, summary))
+		require.Equal(t, http.StatusServiceUnavailable, ComputeStatusCode(false, summary))
+	}
+
+	raw, code := NewInfo(deps).generate(false, "test_drain")
+	require.Equal(t, http.StatusServiceUnavailable, code)
+	require.Contains(t, string(raw), `"condition":"DRAINING"`)
+
+	raw, code = NewPrivate("test_drain", deps).generate(false, "test_drain")
+	require.Equal(t, http.StatusServiceUnavailable, code)
+	require.Contains(t, string(raw), `"condition":"DRAINING"`)
+}
+
+func TestDrainAndShutdown(t *testing.T) {
+	deps := NewBasicDependencySet()
+	defer deps.Close()
+
+	server := httptest.NewServer(NewInfo(deps))
+	defer server.Close()
+
+	received := make(chan os.Signal, 1)
+	received <- syscall.SIGTERM
+	require.NoError(t, drainAndShutdown(context.Background(), server.Config, deps, 10*time.Millisecond, time.Second, received))
+	require.True(t, deps.Draining())
+}
+
+func TestDrainAndShutdown_cancelledDuringPeriod(t *testing.T) {
+	deps := NewBasicDependencySet()
+	defer deps.Close()
+
+	server := httptest.NewServer(NewInfo(deps))
+	defer server.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	received := make(chan os.Signal, 1)
+	received <- syscall.SIGTERM
+	done := make(chan error, 1)
+	go func() {
+		done <- drainAndShutdown(ctx, server.Config, deps, time.Hour, time.Second, received)
+	}()
+
+	for !deps.Draining() {
+		time.Sleep(time.Millisecond)
+	}
+	cancel()
+	require.NoError(t, <-done)
+}
+
+func TestDrainAndShutdown_timeout(t *testing.T) {
+	deps := NewBasicDependencySet()
+	defer deps.Close()
+
+	// a long-lived request keeps its connection active
+	started := make(chan struct{})
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		close(started)
+		<-release
+	}))
+	defer server.Close()
+	defer close(release)
+	go func() {
+		if response, err := http.Get(server.URL); err == nil {
+			_ = response.Body.Close()
+		}
+	}()
+	<-started
+
+	received := make(chan os.Signal, 1)
+	received <- syscall.SIGTERM
+	err := drainAndShutdown(context.Background(), server.Config, deps, 0, 10*time.Millisecond, received)
+	require.Equal(t, context.DeadlineExceeded, err)
+}
+
+func TestDrainAndShutdown_cancelled(t *testing.T) {
+	deps := NewBasicDependencySet()
+	defer deps.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	require.Equal(t, context.Canceled, DrainAndShutdown(ctx, &http.Server{}, deps, 0, 0))
+	require.False(t, deps.Draining())
+}
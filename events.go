@@ -0,0 +1,269 @@
+package libhealth
+
+import (
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"oss.indeed.com/go/libhealth/clock"
+)
+
+// EventKind is the kind of change an Event represents.
+type EventKind int
+
+const (
+	// MonitorTransition is a change of the Status of a HealthMonitor.
+	MonitorTransition EventKind = iota
+	// OverallTransition is a change of the Overall Status of a DependencySet.
+	OverallTransition
+)
+
+// String provides a regular string representation of an EventKind.
+func (k EventKind) String() string {
+	switch k {
+	case MonitorTransition:
+		return "MonitorTransition"
+	case OverallTransition:
+		return "OverallTransition"
+	}
+	return "INVALID"
+}
+
+// An Event is a change of Status published by a BasicDependencySet to
+// each of its Subscriptions. The Status of a HealthMonitor is downgraded
+// by its Urgency, the same as in a Summary.
+type Event struct {
+	Kind   EventKind
+	Name   string // of the HealthMonitor, empty for an OverallTransition
+	Time   time.Time
+	Prev   Status
+	Next   Status
+	Health Health // of the HealthMonitor, zero for an OverallTransition
+}
+
+// SubscriptionOption configures optional behavior of a Subscription.
+type SubscriptionOption func(subscription *Subscription)
+
+// WithBuffer configures how many Events a Subscription holds for a slow consumer before dropping new ones. If not
+// provided, 16 Events are held. At least one Event is always held.
+func WithBuffer(size int) SubscriptionOption {
+	return func(subscription *Subscription) {
+		subscription.events = make(chan Event, atLeastOne(size))
+	}
+}
+
+// WithDebounce configures a Subscription to deliver a change of Status only once it has lasted for period. Changes
+// of the same HealthMonitor (or of the Overall Status) within period are combined into one Event, which is not
+// delivered at all if the Status changed back. The period is measured by the Clock of the BasicDependencySet. If not
+// provided, every change is delivered immediately.
+func WithDebounce(period time.Duration) SubscriptionOption {
+	return func(subscription *Subscription) {
+		subscription.debounce = period
+	}
+}
+
+// A Subscription receives the Events published by a BasicDependencySet.
+type Subscription struct {
+	bus      *bus
+	events   chan Event
+	debounce time.Duration
+	clock    clock.Clock // measures debounce
+	dropped  uint64      // atomic
+
+	lock    sync.Mutex // locks the data below and sending to events
+	pending map[pendingKey]*pendingEvent
+	closed  bool
+}
+
+type pendingKey struct {
+	kind EventKind
+	name string
+}
+
+type pendingEvent struct {
+	event Event
+	timer clock.Timer
+}
+
+// Events is the channel Events are delivered on. It is closed by Close.
+func (s *Subscription) Events() <-chan Event {
+	return s.events
+}
+
+// Dropped is the number of Events which were dropped because the buffer of
+// the Subscription was full.
+func (s *Subscription) Dropped() uint64 {
+	return atomic.LoadUint64(&s.dropped)
+}
+
+// Close stops the delivery of Events to s, and closes its channel.
+func (s *Subscription) Close() {
+	s.bus.unsubscribe(s)
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	if s.closed {
+		return
+	}
+	s.closed = true
+	for _, pending := range s.pending {
+		pending.timer.Stop()
+	}
+	close(s.events)
+}
+
+func (s *Subscription) publish(e Event) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	if s.debounce <= 0 {
+		s.deliver(e)
+		return
+	}
+
+	key := pendingKey{kind: e.Kind, name: e.Name}
+	if pending, exists := s.pending[key]; exists {
+		// keep where the change started, but end up wherever it is now
+		pending.timer.Stop()
+		e.Prev = pending.event.Prev
+	}
+	s.pending[key] = &pendingEvent{
+		event: e,
+		timer: clock.AfterFunc(s.clock, s.debounce, func() {
+			s.settle(key)
+		}),
+	}
+}
+
+// settle delivers the pending Event of key, if it is still a change.
+func (s *Subscription) settle(key pendingKey) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	pending, exists := s.pending[key]
+	if !exists || s.closed {
+		return
+	}
+	delete(s.pending, key)
+	if pending.event.Prev != pending.event.Next {
+		s.deliver(pending.event)
+	}
+}
+
+// deliver must be called while holding lock.
+func (s *Subscription) deliver(e Event) {
+	if s.closed {
+		return
+	}
+	select {
+	case s.events <- e:
+	default:
+		atomic.AddUint64(&s.dropped, 1)
+	}
+}
+
+// bus publishes Events to every Subscription.
+type bus struct {
+	clock         clock.Clock // of the BasicDependencySet
+	lock          sync.RWMutex
+	subscriptions map[*Subscription]struct{}
+}
+
+func (b *bus) subscribe(options ...SubscriptionOption) *Subscription {
+	s := &Subscription{
+		bus:     b,
+		events:  make(chan Event, 16),
+		clock:   b.clock,
+		pending: make(map[pendingKey]*pendingEvent),
+	}
+	if s.clock == nil {
+		s.clock = clock.System
+	}
+	for _, option := range options {
+		option(s)
+	}
+
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	if b.subscriptions == nil {
+		b.subscriptions = make(map[*Subscription]struct{})
+	}
+	b.subscriptions[s] = struct{}{}
+	return s
+}
+
+func (b *bus) unsubscribe(s *Subscription) {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	delete(b.subscriptions, s)
+}
+
+func (b *bus) publish(e Event) {
+	b.lock.RLock()
+	defer b.lock.RUnlock()
+
+	for s := range b.subscriptions {
+		s.publish(e)
+	}
+}
+
+// Subscribe creates a new Subscription to the Events of d. The Subscription
+// must be closed once it is no longer used.
+func (d *BasicDependencySet) Subscribe(options ...SubscriptionOption) *Subscription {
+	return d.bus.subscribe(options...)
+}
+
+// SubscribeFunc creates a new Subscription to the Events of d, and calls
+// handler with each Event in a separate goroutine until the Subscription
+// is closed.
+func (d *BasicDependencySet) SubscribeFunc(handler func(Event), options ...SubscriptionOption) *Subscription {
+	s := d.Subscribe(options...)
+	go func() {
+		for e := range s.Events() {
+			handler(e)
+		}
+	}()
+	return s
+}
+
+// publishTransitions publishes the change from prev to next of a
+// HealthMonitor, and the resulting change of the Overall Status, if any.
+// It also wakes up everyone waiting in WaitReady. Without prev and next,
+// such as after an Override or a registration changed, the Overall Status
+// is always recomputed; otherwise only if next counts differently.
+//
+// Must be called while holding lock.
+func (d *BasicDependencySet) publishTransitions(prev, next *Result) {
+	if prev != nil && next != nil {
+		if prev.settled() != next.settled() {
+			d.bus.publish(Event{
+				Kind:   MonitorTransition,
+				Name:   next.name,
+				Time:   next.Time,
+				Prev:   prev.settled(),
+				Next:   next.settled(),
+				Health: next.Health,
+			})
+		} else if prev.pending == next.pending && prev.initial == next.initial {
+			return
+		}
+	}
+
+	now := d.clock.Now()
+	overall := d.summary(d.results()).Overall()
+	if overall != d.overall {
+		d.bus.publish(Event{
+			Kind: OverallTransition,
+			Time: now,
+			Prev: d.overall,
+			Next: overall,
+		})
+		d.overall = overall
+	}
+
+	close(d.changed)
+	d.changed = make(chan struct{})
+}
@@ -0,0 +1,150 @@
+package libhealth
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func receive(t *testing.T, s *Subscription) Event {
+	select {
+	case e := <-s.Events():
+		return e
+	case <-time.After(time.Second):
+		require.FailNow(t, "no event received")
+		return Event{}
+	}
+}
+
+func TestBasicDependencySet_Subscribe(t *testing.T) {
+	deps := NewBasicDependencySetWithOptions(context.Background())
+	defer deps.Close()
+
+	first := deps.Subscribe()
+	defer first.Close()
+	second := deps.Subscribe(WithBuffer(1))
+	defer second.Close()
+
+	deps.Register(NewMonitorWithOptions("flapping", "", "", STRONG, sequenceChecker(OUTAGE, OK)))
+	deps.waitUntilInitialRun()
+	deps.Live()
+
+	// registering a monitor which has not run yet is not a transition,
+	// but its first check failing is
+	e := receive(t, first)
+	require.Equal(t, MonitorTransition, e.Kind)
+	require.Equal(t, "flapping", e.Name)
+	require.Equal(t, OK, e.Prev)
+	require.Equal(t, MAJOR, e.Next)
+
+	e = receive(t, first)
+	require.Equal(t, OverallTransition, e.Kind)
+	require.Equal(t, OK, e.Prev)
+	require.Equal(t, MAJOR, e.Next)
+
+	// the second check recovers
+	e = receive(t, first)
+	require.Equal(t, MonitorTransition, e.Kind)
+	require.Equal(t, "flapping", e.Name)
+	require.Equal(t, MAJOR, e.Prev)
+	require.Equal(t, OK, e.Next)
+	require.Equal(t, Message("OK"), e.Health.Message)
+
+	e = receive(t, first)
+	require.Equal(t, OverallTransition, e.Kind)
+	require.Equal(t, MAJOR, e.Prev)
+	require.Equal(t, OK, e.Next)
+
+	require.Zero(t, first.Dropped())
+	require.Equal(t, uint64(3), second.Dropped())
+
+	second.Close()
+	_, open := <-second.Events()
+	require.True(t, open, "the buffered event is still received")
+	_, open = <-second.Events()
+	require.False(t, open)
+}
+
+func TestSubscription_debounce(t *testing.T) {
+	var b bus
+	s := b.subscribe(WithDebounce(20 * time.Millisecond))
+	defer s.Close()
+
+	// flapping back and forth is not delivered
+	b.publish(Event{Kind: MonitorTransition, Name: "flapping", Prev: OK, Next: OUTAGE})
+	b.publish(Event{Kind: MonitorTransition, Name: "flapping", Prev: OUTAGE, Next: OK})
+
+	// changes which last are delivered once
+	b.publish(Event{Kind: MonitorTransition, Name: "failing", Prev: OK, Next: MAJOR})
+	b.publish(Event{Kind: MonitorTransition, Name: "failing", Prev: MAJOR, Next: OUTAGE})
+
+	e := receive(t, s)
+	require.Equal(t, "failing", e.Name)
+	require.Equal(t, OK, e.Prev)
+	require.Equal(t, OUTAGE, e.Next)
+
+	time.Sleep(30 * time.Millisecond)
+	require.Empty(t, s.Events())
+}
+
+func TestBasicDependencySet_SubscribeFunc(t *testing.T) {
+	deps := NewBasicDependencySetWithOptions(context.Background())
+	defer deps.Close()
+
+	var lock sync.Mutex
+	var events []Event
+	s := deps.SubscribeFunc(func(e Event) {
+		lock.Lock()
+		defer lock.Unlock()
+		events = append(events, e)
+	})
+	defer s.Close()
+
+	deps.Register(NewMonitorWithOptions("failing", "", "", REQUIRED, sequenceChecker(OUTAGE)))
+	deps.waitUntilInitialRun()
+
+	require.Eventually(t, func() bool {
+		lock.Lock()
+		defer lock.Unlock()
+		return len(events) == 2
+	}, time.Second, time.Millisecond)
+	require.Equal(t, OUTAGE, events[0].Next)
+}
+
+func TestBasicDependencySet_publishTransitions_unchanged(t *testing.T) {
+	deps := NewBasicDependencySet(NewMonitorWithOptions("steady", "", "", REQUIRED,
+		sequenceChecker(OK, OK, MAJOR),
+		WithPeriod(0),
+	))
+	defer deps.Close()
+	deps.waitUntilInitialRun()
+
+	changed := func() <-chan struct{} {
+		deps.lock.RLock()
+		defer deps.lock.RUnlock()
+		return deps.changed
+	}
+
+	// a check which does not change the Status does not recompute the Overall Status
+	before := changed()
+	deps.Live()
+	require.Equal(t, before, changed())
+
+	deps.Live()
+	require.NotEqual(t, before, changed())
+	require.Equal(t, MAJOR, deps.Background().Overall())
+}
+
+func TestWithBuffer_atLeastOne(t *testing.T) {
+	var b bus
+	for _, size := range []int{-1, 0} {
+		s := b.subscribe(WithBuffer(size))
+		b.publish(Event{Kind: OverallTransition, Prev: OK, Next: OUTAGE})
+		require.Equal(t, OUTAGE, receive(t, s).Next)
+		require.Zero(t, s.Dropped())
+		s.Close()
+	}
+}
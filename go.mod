@@ -5,6 +5,7 @@ go 1.13
 require (
 	github.com/emirpasic/gods v1.12.0
 	github.com/stretchr/testify v1.6.1
+	gopkg.in/yaml.v3 v3.0.1
 	oss.indeed.com/go/go-groups v1.1.2
 	oss.indeed.com/go/go-opine v1.0.0
 	oss.indeed.com/go/libtime v1.4.0
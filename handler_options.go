@@ -0,0 +1,40 @@
+package libhealth
+
+import (
+	"oss.indeed.com/go/libhealth/clock"
+)
+
+// HandlerOption configures optional behavior of the Info, Private and Trigger handlers.
+type HandlerOption func(config *handlerConfig)
+
+type handlerConfig struct {
+	clock            clock.Clock
+	statusCodePolicy StatusCodePolicy
+}
+
+func newHandlerConfig(statusCodePolicy StatusCodePolicy, options []HandlerOption) handlerConfig {
+	config := handlerConfig{
+		clock:            clock.System,
+		statusCodePolicy: statusCodePolicy,
+	}
+	for _, option := range options {
+		option(&config)
+	}
+	return config
+}
+
+// WithHandlerClock configures the Clock used to measure the uptime of the application, and by Trigger to timestamp the
+// Summary of its response. If not provided, clock.System is used.
+func WithHandlerClock(c clock.Clock) HandlerOption {
+	return func(config *handlerConfig) {
+		config.clock = c
+	}
+}
+
+// WithStatusCodePolicy configures how the Overall Status maps to the HTTP status code of responses. If not provided,
+// InfoStatusCodes is used by Info, and PrivateStatusCodes by Private and Trigger.
+func WithStatusCodePolicy(policy StatusCodePolicy) HandlerOption {
+	return func(config *handlerConfig) {
+		config.statusCodePolicy = policy
+	}
+}
@@ -8,31 +8,75 @@ import (
 // Message is a string with some useful information regarding a Health.
 type Message string
 
+// Details are structured values describing a Health, such as measurements
+// taken by the check which produced it. The values should be able to be
+// encoded as JSON.
+type Details map[string]interface{}
+
 // Health is a representation of the health of a service at a moment in time.
-// It is composed of a Status, an Urgency, a Time, and a Message. Once created
-// it should not be modified.
+// It is composed of a Status, an Urgency, a Time, and a Message, along with
+// optional Details and the error which caused it. Once created it should not
+// be modified; use With and WithError to create a modified copy instead.
 type Health struct {
 	Status
 	Urgency
 	time.Time
 	Message
 	time.Duration
+
+	Details Details
+	Err     error
+
+	// observed is the Health the check actually produced, when a
+	// Monitor is holding back a different Health from being reported.
+	observed *Health
+
+	// nested is the Summary of a DependencySet, when the Health is of the
+	// whole set.
+	nested *Summary
 }
 
 // NewHealth creates a Health for a fixed moment in time.
 func NewHealth(
 	state Status,
 	message string,
+) Health {
+	return NewHealthAt(state, message, time.Now())
+}
+
+// NewHealthAt creates a Health for the moment t, such as the time of a
+// clock.Clock.
+func NewHealthAt(
+	state Status,
+	message string,
+	t time.Time,
 ) Health {
 	return Health{
 		Status:   state,
 		Urgency:  UNKNOWN, // set by the owning Monitor
-		Time:     time.Now(),
+		Time:     t,
 		Message:  Message(message),
 		Duration: 0,
 	}
 }
 
+// With returns a copy of h with the detail key set to value.
+func (h Health) With(key string, value interface{}) Health {
+	details := make(Details, len(h.Details)+1)
+	for k, v := range h.Details {
+		details[k] = v
+	}
+	details[key] = value
+	h.Details = details
+	return h
+}
+
+// WithError returns a copy of h with the error which caused it set to err.
+func (h Health) WithError(err error) Health {
+	h.Err = err
+	return h
+}
+
 // String returns a human readable summary
 func (h Health) String() string {
 	return fmt.Sprintf(
@@ -43,3 +87,10 @@ func (h Health) String() string {
 		h.Message,
 	)
 }
+
+// UnmarshalJSON decodes the Time of h, as it did before Status and Urgency
+// could decode JSON themselves, matching the MarshalJSON of time.Time which
+// Health still promotes.
+func (h *Health) UnmarshalJSON(data []byte) error {
+	return h.Time.UnmarshalJSON(data)
+}
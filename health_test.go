@@ -1,6 +1,8 @@
 package libhealth
 
 import (
+	"encoding/json"
+	"errors"
 	"testing"
 	"time"
 
@@ -27,3 +29,29 @@ func (s *HealthSuite) TestString() {
 	exp := "MAJOR STRONG at 2015-12-14 11:19:00 +0000 UTC, this is a test"
 	require.Equal(s.T(), exp, h.String())
 }
+
+func (s *HealthSuite) TestWith() {
+	h := NewHealth(OK, "fine").With("lag_ms", 120)
+	withPool := h.With("pool_used", 0.5)
+
+	require.Equal(s.T(), Details{"lag_ms": 120}, h.Details, "the original is not modified")
+	require.Equal(s.T(), Details{"lag_ms": 120, "pool_used": 0.5}, withPool.Details)
+}
+
+func (s *HealthSuite) TestWithError() {
+	err := errors.New("connection refused")
+	h := NewHealth(OUTAGE, "database is down").WithError(err)
+
+	require.Equal(s.T(), err, h.Err)
+	require.Equal(s.T(), Message("database is down"), h.Message)
+}
+
+func (s *HealthSuite) TestJSON() {
+	h := NewHealth(OK, "fine")
+	b, err := json.Marshal(h)
+	require.NoError(s.T(), err)
+
+	var decoded Health
+	require.NoError(s.T(), json.Unmarshal(b, &decoded))
+	require.True(s.T(), h.Time.Equal(decoded.Time), "Health is still encoded as its Time")
+}
@@ -0,0 +1,240 @@
+package libhealth
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"oss.indeed.com/go/libtime"
+)
+
+// PrivateHealthCheckHistory is the endpoint serving the History of each
+// HealthMonitor. Adding the id query parameter limits it to one HealthMonitor.
+const PrivateHealthCheckHistory = `/private/healthcheck/history`
+
+// History is the recent past of a HealthMonitor, as kept by a
+// BasicDependencySet configured with WithHistory. Both are ordered from
+// the oldest to the most recent.
+type History struct {
+	Results     []HistoryEntry
+	Transitions []Transition
+}
+
+// A HistoryEntry is a past Result of a HealthMonitor.
+type HistoryEntry struct {
+	Time     time.Time
+	Duration time.Duration
+	Status   Status // downgraded by Urgency
+	Message  string
+}
+
+// A Transition is a change of the Status of a HealthMonitor.
+type Transition struct {
+	Time     time.Time
+	Duration time.Duration // how long the HealthMonitor was in From
+	From     Status
+	To       Status
+	Message  string
+}
+
+// history keeps a bounded number of the most recent results and
+// transitions of a registration.
+type history struct {
+	results     *ring
+	transitions *ring
+	since       time.Time // of the most recent transition
+}
+
+func newHistory(size int, registered time.Time) *history {
+	if size <= 0 {
+		return nil
+	}
+	return &history{
+		results:     newRing(size),
+		transitions: newRing(size),
+		since:       registered,
+	}
+}
+
+func (h *history) record(prev Status, result *Result) {
+	if h == nil {
+		return
+	}
+
+	h.results.add(HistoryEntry{
+		Time:     result.Time,
+		Duration: result.Duration,
+		Status:   result.Status,
+		Message:  string(result.Message),
+	})
+
+	if result.pending {
+		return
+	}
+
+	if prev != result.Status {
+		h.transitions.add(Transition{
+			Time:     result.Time,
+			Duration: result.Time.Sub(h.since),
+			From:     prev,
+			To:       result.Status,
+			Message:  string(result.Message),
+		})
+		h.since = result.Time
+	}
+}
+
+func (h *history) snapshot() History {
+	var snapshot History
+	if h == nil {
+		return snapshot
+	}
+
+	h.results.each(func(v interface{}) {
+		snapshot.Results = append(snapshot.Results, v.(HistoryEntry))
+	})
+	h.transitions.each(func(v interface{}) {
+		snapshot.Transitions = append(snapshot.Transitions, v.(Transition))
+	})
+	return snapshot
+}
+
+// ring is a fixed size buffer which overwrites its oldest values.
+type ring struct {
+	values []interface{}
+	next   int
+	full   bool
+}
+
+func newRing(size int) *ring {
+	return &ring{values: make([]interface{}, size)}
+}
+
+func (r *ring) add(v interface{}) {
+	r.values[r.next] = v
+	r.next++
+	if r.next == len(r.values) {
+		r.next = 0
+		r.full = true
+	}
+}
+
+// each calls f with the values of r, from the oldest to the newest.
+func (r *ring) each(f func(v interface{})) {
+	if r.full {
+		for _, v := range r.values[r.next:] {
+			f(v)
+		}
+	}
+	for _, v := range r.values[:r.next] {
+		f(v)
+	}
+}
+
+// HistoryHandler is an http.Handler for
+//
+//	/private/healthcheck/history
+//
+// which serves the History of each HealthMonitor of a BasicDependencySet.
+type HistoryHandler struct {
+	set *BasicDependencySet
+}
+
+// NewHistoryHandler creates a new HistoryHandler for the HealthMonitors of set.
+func NewHistoryHandler(set *BasicDependencySet) *HistoryHandler {
+	return &HistoryHandler{set: set}
+}
+
+// HistoryResult is the body of a history endpoint response, keyed by
+// the id of each HealthMonitor.
+type HistoryResult map[string]HistoryComponent
+
+// A HistoryComponent is the history of one component in a
+// /private/healthcheck/history result.
+type HistoryComponent struct {
+	Results     []HistoryComponentEntry `json:"results"`
+	Transitions []HistoryTransition     `json:"transitions"`
+}
+
+// A HistoryComponentEntry is one past result of a component.
+type HistoryComponentEntry struct {
+	Timestamp int64  `json:"timestamp"`
+	Date      string `json:"date"`
+	State     string `json:"status"`
+	Message   string `json:"errorMessage"`
+	Duration  int64  `json:"duration"`
+}
+
+// A HistoryTransition is one change of the status of a component.
+type HistoryTransition struct {
+	Timestamp int64  `json:"timestamp"`
+	Date      string `json:"date"`
+	From      string `json:"from"`
+	To        string `json:"to"`
+	Message   string `json:"errorMessage"`
+	Duration  int64  `json:"duration"`
+}
+
+func copyHistory(h History) HistoryComponent {
+	c := HistoryComponent{
+		Results:     make([]HistoryComponentEntry, 0, len(h.Results)),
+		Transitions: make([]HistoryTransition, 0, len(h.Transitions)),
+	}
+	for _, entry := range h.Results {
+		c.Results = append(c.Results, HistoryComponentEntry{
+			Timestamp: libtime.ToMilliseconds(entry.Time),
+			Date:      entry.Time.Format(timeFormat),
+			State:     entry.Status.String(),
+			Message:   entry.Message,
+			Duration:  entry.Duration.Nanoseconds() / 1000,
+		})
+	}
+	for _, transition := range h.Transitions {
+		c.Transitions = append(c.Transitions, HistoryTransition{
+			Timestamp: libtime.ToMilliseconds(transition.Time),
+			Date:      transition.Time.Format(timeFormat),
+			From:      transition.From.String(),
+			To:        transition.To.String(),
+			Message:   transition.Message,
+			Duration:  transition.Duration.Nanoseconds() / 1000,
+		})
+	}
+	return c
+}
+
+func (h *HistoryHandler) generate(id string) ([]byte, int) {
+	result := make(HistoryResult)
+	if id == "" {
+		for name, past := range h.set.Histories() {
+			result[name] = copyHistory(past)
+		}
+	} else {
+		past, exists := h.set.History(id)
+		if !exists {
+			return errorJSON(ErrUnknownMonitor), http.StatusNotFound
+		}
+		result[id] = copyHistory(past)
+	}
+
+	body, err := json.Marshal(result)
+	if err != nil {
+		return []byte(privateBad), http.StatusInternalServerError
+	}
+	return body, http.StatusOK
+}
+
+func (h *HistoryHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	body, code := h.generate(r.URL.Query().Get("id"))
+	var prettyJSON bytes.Buffer
+	if err := json.Indent(&prettyJSON, body, "", "  "); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.WriteHeader(code)
+	if _, err := w.Write(prettyJSON.Bytes()); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+}
@@ -0,0 +1,111 @@
+package libhealth
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func Test_ring(t *testing.T) {
+	r := newRing(3)
+	values := func() []interface{} {
+		var values []interface{}
+		r.each(func(v interface{}) {
+			values = append(values, v)
+		})
+		return values
+	}
+
+	require.Empty(t, values())
+	r.add(1)
+	r.add(2)
+	require.Equal(t, []interface{}{1, 2}, values())
+	r.add(3)
+	r.add(4)
+	require.Equal(t, []interface{}{2, 3, 4}, values())
+}
+
+func TestBasicDependencySet_History(t *testing.T) {
+	deps := NewBasicDependencySetWithOptions(context.Background(), WithHistory(3))
+	defer deps.Close()
+	deps.Register(NewMonitorWithOptions("flapping", "", "", STRONG,
+		sequenceChecker(OK, OUTAGE, OUTAGE, OK),
+	))
+	deps.waitUntilInitialRun()
+	for i := 0; i < 3; i++ {
+		deps.Live()
+	}
+
+	history, exists := deps.History("flapping")
+	require.True(t, exists)
+
+	require.Len(t, history.Results, 3)
+	require.Equal(t, MAJOR, history.Results[0].Status, "the status is downgraded by urgency")
+	require.Equal(t, "OUTAGE", history.Results[0].Message)
+	require.Equal(t, MAJOR, history.Results[1].Status)
+	require.Equal(t, OK, history.Results[2].Status)
+
+	require.Len(t, history.Transitions, 2, "the first check being OK is not a transition")
+	require.Equal(t, []Status{OK, MAJOR}, []Status{
+		history.Transitions[0].From,
+		history.Transitions[1].From,
+	})
+	require.Equal(t, []Status{MAJOR, OK}, []Status{
+		history.Transitions[0].To,
+		history.Transitions[1].To,
+	})
+
+	_, exists = deps.History("missing")
+	require.False(t, exists)
+}
+
+func Test_HistoryHandler_ServeHTTP(t *testing.T) {
+	deps := NewBasicDependencySetWithOptions(context.Background(), WithHistory(3))
+	defer deps.Close()
+	deps.Register(
+		NewMonitorWithOptions("first", "", "", REQUIRED, sequenceChecker(OUTAGE)),
+		NewMonitorWithOptions("second", "", "", REQUIRED, sequenceChecker(OK)),
+	)
+	deps.waitUntilInitialRun()
+
+	mux := http.NewServeMux()
+	WrapServeMuxAdmin(mux, deps)
+
+	w := httptest.NewRecorder()
+	mux.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/private/healthcheck/history", nil))
+	require.Equal(t, http.StatusOK, w.Code)
+	var result HistoryResult
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &result))
+	require.Len(t, result, 2)
+
+	w = httptest.NewRecorder()
+	mux.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/private/healthcheck/history?id=first", nil))
+	require.Equal(t, http.StatusOK, w.Code)
+	result = nil
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &result))
+	require.Len(t, result, 1)
+	require.Len(t, result["first"].Results, 1)
+	require.Equal(t, "OUTAGE", result["first"].Results[0].State)
+	require.Len(t, result["first"].Transitions, 1)
+	require.Equal(t, "OK", result["first"].Transitions[0].From)
+
+	w = httptest.NewRecorder()
+	mux.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/private/healthcheck/history?id=missing", nil))
+	require.Equal(t, http.StatusNotFound, w.Code)
+}
+
+func Test_WrapServeMuxAdmin_withoutHistory(t *testing.T) {
+	deps := NewBasicDependencySet()
+	defer deps.Close()
+
+	mux := http.NewServeMux()
+	WrapServeMuxAdmin(mux, deps)
+
+	w := httptest.NewRecorder()
+	mux.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/private/healthcheck/history", nil))
+	require.Equal(t, http.StatusNotFound, w.Code)
+}
@@ -19,12 +19,13 @@ const (
 //	/info/healthcheck
 //	/info/healthcheck/live.
 type Info struct {
+	handlerConfig
 	deps DependencySet
 }
 
 // NewInfo will create a new Info handler for a given DependencySet set.
-func NewInfo(d DependencySet) *Info {
-	return &Info{d}
+func NewInfo(d DependencySet, options ...HandlerOption) *Info {
+	return &Info{handlerConfig: newHandlerConfig(InfoStatusCodes, options), deps: d}
 }
 
 // InfoResult represents the body of an info healthcheck.
@@ -43,7 +44,7 @@ func (i *Info) generate(live bool, hostname string) ([]byte, int) {
 	}
 
 	r := InfoResult{
-		Condition: s.Overall().String(),
+		Condition: s.condition(),
 		Hostname:  hostname,
 		Duration:  int64(s.Duration()) / 1000000,
 	}
@@ -52,7 +53,7 @@ func (i *Info) generate(live bool, hostname string) ([]byte, int) {
 	if err != nil {
 		return []byte(infoBad), http.StatusInternalServerError
 	}
-	return bytes, ComputeStatusCode(true, s)
+	return bytes, i.statusCodePolicy.code(s)
 }
 
 // ServeHTTP is intended to be used by a net/http.ServeMux for serving formatted json.
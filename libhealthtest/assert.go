@@ -0,0 +1,91 @@
+package libhealthtest
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"oss.indeed.com/go/libhealth"
+)
+
+// Timeouts of the Eventually assertions, which may be changed for slower
+// environments.
+var (
+	EventuallyWaitFor = 5 * time.Second
+	EventuallyTick    = 10 * time.Millisecond
+)
+
+// TestingT is the subset of testing.TB used by the assertions.
+type TestingT interface {
+	Errorf(format string, args ...interface{})
+	Helper()
+}
+
+// AssertOverall asserts that the Overall Status of the cached Health of set
+// is status.
+func AssertOverall(t TestingT, set libhealth.DependencySet, status libhealth.Status) bool {
+	t.Helper()
+	return assert.Equal(t, status, set.Background().Overall(), "overall status")
+}
+
+// AssertEventuallyOverall asserts that the Overall Status of the cached
+// Health of set becomes status within EventuallyWaitFor.
+func AssertEventuallyOverall(t TestingT, set libhealth.DependencySet, status libhealth.Status) bool {
+	t.Helper()
+	return assert.Eventually(t, func() bool {
+		return set.Background().Overall() == status
+	}, EventuallyWaitFor, EventuallyTick, "overall status never became %s", status)
+}
+
+// ServePrivate requests path from handler, such as the libhealth.Private
+// handler or a ServeMux wrapped by libhealth.WrapServeMux, and decodes the
+// response as a libhealth.PrivateResult.
+func ServePrivate(t TestingT, handler http.Handler, path string) (int, libhealth.PrivateResult) {
+	t.Helper()
+
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, httptest.NewRequest(http.MethodGet, path, nil))
+
+	var result libhealth.PrivateResult
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &result), "decoding %s", path)
+	return w.Code, result
+}
+
+// FindComponent returns the component with id in result, along with the
+// status it is listed under, or false if there is no such component.
+func FindComponent(result libhealth.PrivateResult, id string) (libhealth.Component, string, bool) {
+	buckets := []struct {
+		status     string
+		components []libhealth.Component
+	}{
+		{libhealth.OUTAGE.String(), result.Results.Outage},
+		{libhealth.MAJOR.String(), result.Results.Major},
+		{libhealth.MINOR.String(), result.Results.Minor},
+		{libhealth.OK.String(), result.Results.Ok},
+		{libhealth.Pending, result.Results.Pending},
+	}
+	for _, bucket := range buckets {
+		for _, component := range bucket.components {
+			if component.ID == id {
+				return component, bucket.status, true
+			}
+		}
+	}
+	return libhealth.Component{}, "", false
+}
+
+// AssertComponent asserts that result has the component with id, listed
+// under status, such as "OUTAGE" or libhealth.Pending.
+func AssertComponent(t TestingT, result libhealth.PrivateResult, id, status string) bool {
+	t.Helper()
+
+	_, listed, found := FindComponent(result, id)
+	if !assert.True(t, found, "no component %q", id) {
+		return false
+	}
+	return assert.True(t, strings.EqualFold(status, listed), "component %q is %s, not %s", id, listed, status)
+}
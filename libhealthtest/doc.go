@@ -0,0 +1,4 @@
+// Package libhealthtest provides test doubles and assertions for code which
+// uses libhealth, such as a scriptable FakeMonitor, a Recorder of transition
+// Events, and helpers for the healthcheck endpoints.
+package libhealthtest
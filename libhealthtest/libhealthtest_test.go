@@ -0,0 +1,118 @@
+package libhealthtest
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"oss.indeed.com/go/libhealth"
+)
+
+type recordingT struct {
+	errors []string
+}
+
+func (r *recordingT) Errorf(format string, args ...interface{}) {
+	r.errors = append(r.errors, fmt.Sprintf(format, args...))
+}
+
+func (r *recordingT) Helper() {}
+
+func TestFakeMonitor(t *testing.T) {
+	fake := NewFakeMonitor("fake", libhealth.REQUIRED).ReturnsStatus(libhealth.MAJOR, libhealth.OK)
+
+	ctx := context.Background()
+	require.Equal(t, libhealth.MAJOR, fake.Check(ctx).Status)
+	require.Equal(t, libhealth.OK, fake.Check(ctx).Status)
+	require.Equal(t, libhealth.OK, fake.Check(ctx).Status, "the last Health is repeated")
+	require.Equal(t, 3, fake.Checks())
+}
+
+func TestFakeMonitor_Block(t *testing.T) {
+	fake := NewFakeMonitor("fake", libhealth.REQUIRED)
+	fake.Block()
+
+	healths := make(chan libhealth.Health)
+	go func() {
+		healths <- fake.Check(context.Background())
+	}()
+
+	select {
+	case <-healths:
+		t.Fatal("check was not blocked")
+	case <-time.After(10 * time.Millisecond):
+	}
+
+	fake.Release()
+	require.Equal(t, libhealth.OK, (<-healths).Status)
+
+	fake.Block()
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	require.Equal(t, libhealth.OUTAGE, fake.Check(ctx).Status)
+}
+
+func TestRecorder(t *testing.T) {
+	set := libhealth.NewBasicDependencySet()
+	defer set.Close()
+	recorder := NewRecorder(set)
+	defer recorder.Close()
+
+	fake := NewFakeMonitor("fake", libhealth.REQUIRED).ReturnsStatus(libhealth.OUTAGE)
+	set.Register(fake)
+
+	AssertEventuallyOverall(t, set, libhealth.OUTAGE)
+	require.Eventually(t, func() bool {
+		return len(recorder.Overall()) == 1
+	}, time.Second, time.Millisecond)
+
+	transitions := recorder.Transitions("fake")
+	require.Len(t, transitions, 1)
+	require.Equal(t, libhealth.OK, transitions[0].Prev)
+	require.Equal(t, libhealth.OUTAGE, transitions[0].Next)
+	require.Len(t, recorder.Events(), 2)
+	require.Zero(t, recorder.Dropped())
+}
+
+func TestAssertOverall(t *testing.T) {
+	set := libhealth.NewBasicDependencySet()
+	defer set.Close()
+
+	require.True(t, AssertOverall(t, set, libhealth.OK))
+
+	failing := new(recordingT)
+	require.False(t, AssertOverall(failing, set, libhealth.MAJOR))
+	require.Len(t, failing.errors, 1)
+}
+
+func TestServePrivate(t *testing.T) {
+	set := libhealth.NewBasicDependencySet(
+		NewFakeMonitor("failing", libhealth.WEAK).ReturnsStatus(libhealth.OUTAGE),
+		NewFakeMonitor("passing", libhealth.REQUIRED),
+	)
+	defer set.Close()
+	AssertEventuallyOverall(t, set, libhealth.MINOR)
+
+	mux := http.NewServeMux()
+	libhealth.WrapServeMux(mux, "test", set)
+
+	code, result := ServePrivate(t, mux, libhealth.PrivateHealthCheck)
+	require.Equal(t, http.StatusInternalServerError, code)
+	require.Equal(t, "MINOR", result.Condition)
+	AssertComponent(t, result, "failing", "MINOR")
+	AssertComponent(t, result, "passing", "OK")
+
+	component, status, found := FindComponent(result, "passing")
+	require.True(t, found)
+	require.Equal(t, "OK", status)
+	require.Equal(t, "fake passing", component.Description)
+
+	failing := new(recordingT)
+	require.False(t, AssertComponent(failing, result, "missing", "OK"))
+	require.False(t, AssertComponent(failing, result, "failing", "OK"))
+	require.Len(t, failing.errors, 2)
+}
@@ -0,0 +1,106 @@
+package libhealthtest
+
+import (
+	"context"
+	"sync"
+
+	"oss.indeed.com/go/libhealth"
+)
+
+// FakeMonitor is a libhealth.Monitor whose checks return a scripted
+// sequence of Health, and which can be made to block until released.
+type FakeMonitor struct {
+	*libhealth.Monitor
+
+	lock    sync.Mutex
+	script  []libhealth.Health
+	checks  int
+	blocked chan struct{} // closed by Release, nil unless blocking
+}
+
+// NewFakeMonitor creates a FakeMonitor which returns OK until told otherwise
+// by Returns. The options configure the underlying libhealth.Monitor.
+func NewFakeMonitor(name string, urgency libhealth.Urgency, options ...libhealth.MonitorOption) *FakeMonitor {
+	f := &FakeMonitor{
+		script: []libhealth.Health{libhealth.NewHealth(libhealth.OK, "ok")},
+	}
+	f.Monitor = libhealth.NewMonitorWithOptions(name, "fake "+name, "", urgency, f.check, options...)
+	return f
+}
+
+// Returns scripts the next checks of f to return healths in order, after
+// which the last of them is returned again.
+func (f *FakeMonitor) Returns(healths ...libhealth.Health) *FakeMonitor {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	f.script = healths
+	return f
+}
+
+// ReturnsStatus is like Returns, with a Health of each of statuses whose
+// message is the name of the Status.
+func (f *FakeMonitor) ReturnsStatus(statuses ...libhealth.Status) *FakeMonitor {
+	healths := make([]libhealth.Health, 0, len(statuses))
+	for _, status := range statuses {
+		healths = append(healths, libhealth.NewHealth(status, status.String()))
+	}
+	return f.Returns(healths...)
+}
+
+// Block makes the next checks of f block until Release is called, or their
+// context is done, in which case they return an OUTAGE.
+func (f *FakeMonitor) Block() {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	if f.blocked == nil {
+		f.blocked = make(chan struct{})
+	}
+}
+
+// Release unblocks the checks of f blocked by Block.
+func (f *FakeMonitor) Release() {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	if f.blocked != nil {
+		close(f.blocked)
+		f.blocked = nil
+	}
+}
+
+// Checks returns how many checks of f have started.
+func (f *FakeMonitor) Checks() int {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	return f.checks
+}
+
+func (f *FakeMonitor) check(ctx context.Context) libhealth.Health {
+	f.lock.Lock()
+	f.checks++
+	blocked := f.blocked
+	f.lock.Unlock()
+
+	if blocked != nil {
+		select {
+		case <-blocked:
+		case <-ctx.Done():
+			return libhealth.NewHealth(libhealth.OUTAGE, ctx.Err().Error())
+		}
+	}
+
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	if len(f.script) == 0 {
+		return libhealth.NewHealth(libhealth.OK, "ok")
+	}
+	h := f.script[0]
+	if len(f.script) > 1 {
+		f.script = f.script[1:]
+	}
+	return h
+}
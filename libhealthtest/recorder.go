@@ -0,0 +1,74 @@
+package libhealthtest
+
+import (
+	"sync"
+
+	"oss.indeed.com/go/libhealth"
+)
+
+// Recorder records the transition Events of a BasicDependencySet.
+type Recorder struct {
+	subscription *libhealth.Subscription
+
+	lock   sync.Mutex
+	events []libhealth.Event
+}
+
+// NewRecorder subscribes a Recorder to the transitions of set, until Close
+// is called.
+func NewRecorder(set *libhealth.BasicDependencySet, options ...libhealth.SubscriptionOption) *Recorder {
+	r := new(Recorder)
+	r.subscription = set.SubscribeFunc(r.record, options...)
+	return r
+}
+
+func (r *Recorder) record(e libhealth.Event) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	r.events = append(r.events, e)
+}
+
+// Events returns the Events recorded so far, in order.
+func (r *Recorder) Events() []libhealth.Event {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	events := make([]libhealth.Event, len(r.events))
+	copy(events, r.events)
+	return events
+}
+
+// Transitions returns the MonitorTransition Events of the HealthMonitor
+// named name recorded so far, in order.
+func (r *Recorder) Transitions(name string) []libhealth.Event {
+	var transitions []libhealth.Event
+	for _, e := range r.Events() {
+		if e.Kind == libhealth.MonitorTransition && e.Name == name {
+			transitions = append(transitions, e)
+		}
+	}
+	return transitions
+}
+
+// Overall returns the OverallTransition Events recorded so far, in order.
+func (r *Recorder) Overall() []libhealth.Event {
+	var transitions []libhealth.Event
+	for _, e := range r.Events() {
+		if e.Kind == libhealth.OverallTransition {
+			transitions = append(transitions, e)
+		}
+	}
+	return transitions
+}
+
+// Dropped returns how many Events were dropped rather than recorded,
+// because the Recorder fell behind.
+func (r *Recorder) Dropped() uint64 {
+	return r.subscription.Dropped()
+}
+
+// Close unsubscribes r. The Events recorded so far are kept.
+func (r *Recorder) Close() {
+	r.subscription.Close()
+}
@@ -2,8 +2,11 @@ package libhealth
 
 import (
 	"context"
+	"fmt"
 	"sync"
 	"time"
+
+	"oss.indeed.com/go/libhealth/clock"
 )
 
 var (
@@ -23,10 +26,22 @@ type Monitor struct {
 	checker     HealthChecker
 	statusChan  chan HealthStatus
 
-	previous Health
-	lastOk   time.Time
-	failed   int
-	lock     sync.RWMutex // locks above data
+	failureThreshold  int
+	recoveryThreshold int
+	scheduler         Scheduler
+	parents           []string
+	tags              []string
+	gracePeriod       time.Duration
+	clock             clock.Clock
+
+	created   time.Time
+	previous  Health
+	reported  Status // the Status last published on statusChan
+	settled   bool   // whether the grace period is over, see WithGracePeriod
+	lastOk    time.Time
+	failed    int
+	recovered int
+	lock      sync.RWMutex // locks above data
 }
 
 var _ HealthMonitor = (*Monitor)(nil)
@@ -51,14 +66,21 @@ func NewMonitorWithOptions(
 		checker:     check,
 		statusChan:  nil,
 
-		previous: NewHealth(OK, "starting up"),
-		lastOk:   epoch,
-		failed:   0,
+		failureThreshold:  1,
+		recoveryThreshold: 1,
+		clock:             clock.System,
+
+		previous:  NewHealth(OK, "starting up"),
+		reported:  OK,
+		lastOk:    epoch,
+		failed:    0,
+		recovered: 0,
 	}
 
 	for _, option := range options {
 		option(monitor)
 	}
+	monitor.created = monitor.clock.Now()
 
 	return monitor
 }
@@ -87,37 +109,85 @@ type HealthStatus struct {
 }
 
 // Check will execute the HealthChecker associated with the monitor.
+// The result is not published on the status channel during the grace period.
 func (m *Monitor) Check(ctx context.Context) Health {
-	prev, next := m.checkOnce(ctx)
+	prev, next, settled := m.checkOnce(ctx)
 
-	m.record(next, prev.Status)
+	if settled {
+		m.record(next, prev)
+	}
 	return next
 }
 
-func (m *Monitor) checkOnce(ctx context.Context) (prev, next Health) {
-	startTime := time.Now()
-	next = m.checker(ctx)
-	endTime := time.Now()
+func (m *Monitor) checkOnce(ctx context.Context) (prev Status, next Health, settled bool) {
+	if _, ok := clock.FromContext(ctx); !ok {
+		ctx = clock.NewContext(ctx, m.clock)
+	}
+	startTime := m.clock.Now()
+	observed := m.checker(ctx)
+	endTime := m.clock.Now()
 
-	next.Urgency = m.urgency
-	next.Time = startTime
-	next.Duration = endTime.Sub(startTime)
+	observed.Urgency = m.urgency
+	observed.Time = startTime
+	observed.Duration = endTime.Sub(startTime)
 
 	m.lock.Lock()
 	{
-		if next.SameAs(OK) {
+		if observed.SameAs(OK) {
 			m.lastOk = endTime
 			m.failed = 0
+			m.recovered++
 		} else {
 			m.failed++
+			m.recovered = 0
 		}
 
-		prev = m.previous
+		next = m.damp(m.previous, observed)
 		m.previous = next
+
+		settled = m.settle(next, endTime)
+		prev = m.reported
+		if settled {
+			m.reported = next.Status
+		}
 	}
 	m.lock.Unlock()
 
-	return prev, next
+	return prev, next, settled
+}
+
+// settle reports whether next is published, which it is unless it is not OK
+// during the grace period, before any check has been OK.
+//
+// Must be called while holding lock.
+func (m *Monitor) settle(next Health, now time.Time) bool {
+	if !m.settled && (next.SameAs(OK) || now.Sub(m.created) >= m.gracePeriod) {
+		m.settled = true
+	}
+	return m.settled
+}
+
+// damp keeps reporting the Status of prev until the observed Health has
+// been failing (or recovering) for enough consecutive checks. The Health
+// actually observed is kept along with the one reported.
+//
+// Must be called while holding lock.
+func (m *Monitor) damp(prev, observed Health) Health {
+	var message string
+	switch {
+	case observed.SameAs(OK) && !prev.SameAs(OK) && m.recovered < m.recoveryThreshold:
+		message = fmt.Sprintf("%s, %d of %d consecutive successes", observed.Message, m.recovered, m.recoveryThreshold)
+	case !observed.SameAs(OK) && prev.SameAs(OK) && m.failed < m.failureThreshold:
+		message = fmt.Sprintf("%s, %d of %d consecutive failures", observed.Message, m.failed, m.failureThreshold)
+	default:
+		return observed
+	}
+
+	held := observed
+	held.Status = prev.Status
+	held.Message = Message(message)
+	held.observed = &observed
+	return held
 }
 
 func (m *Monitor) record(next Health, prev Status) {
@@ -161,6 +231,26 @@ func (m *Monitor) Urgency() Urgency {
 	return m.urgency
 }
 
+// Parents are the names of the monitors configured by WithParents.
+func (m *Monitor) Parents() []string {
+	return m.parents
+}
+
+// GracePeriod is the grace period configured by WithGracePeriod.
+func (m *Monitor) GracePeriod() time.Duration {
+	return m.gracePeriod
+}
+
+// Tags are the tags configured by WithTags.
+func (m *Monitor) Tags() []string {
+	return m.tags
+}
+
+// Scheduler is the Scheduler configured by WithScheduler, or nil.
+func (m *Monitor) Scheduler() Scheduler {
+	return m.scheduler
+}
+
 func (m *Monitor) LastOk() time.Time {
 	m.lock.RLock()
 	defer m.lock.RUnlock()
@@ -1,6 +1,10 @@
 package libhealth
 
-import "time"
+import (
+	"time"
+
+	"oss.indeed.com/go/libhealth/clock"
+)
 
 type MonitorOption func(monitor *Monitor)
 
@@ -24,3 +28,71 @@ func WithPeriod(period time.Duration) MonitorOption {
 		monitor.period = period
 	}
 }
+
+// WithFailureThreshold configures the number of consecutive failed checks needed before the monitor reports
+// anything other than OK. Until then the monitor keeps reporting OK. If not provided, the first failure is reported.
+func WithFailureThreshold(n int) MonitorOption {
+	return func(monitor *Monitor) {
+		monitor.failureThreshold = atLeastOne(n)
+	}
+}
+
+// WithRecoveryThreshold configures the number of consecutive OK checks needed before a failing monitor reports OK
+// again. Until then the monitor keeps reporting the failure. If not provided, the first OK is reported.
+func WithRecoveryThreshold(n int) MonitorOption {
+	return func(monitor *Monitor) {
+		monitor.recoveryThreshold = atLeastOne(n)
+	}
+}
+
+func atLeastOne(n int) int {
+	if n < 1 {
+		return 1
+	}
+	return n
+}
+
+// WithScheduler configures the Scheduler a BasicDependencySet uses to run the monitor's background checks. If not
+// provided, the default Scheduler of the BasicDependencySet is used.
+func WithScheduler(scheduler Scheduler) MonitorOption {
+	return func(monitor *Monitor) {
+		monitor.scheduler = scheduler
+	}
+}
+
+// WithParents configures the names of other monitors that the monitor depends on. While a parent is failing at least
+// as severely, once both are downgraded by their urgency, a failure of the monitor is reported as blocked by the
+// parent, and does not count towards the overall health.
+func WithParents(names ...string) MonitorOption {
+	return func(monitor *Monitor) {
+		monitor.parents = names
+	}
+}
+
+// WithTags configures tags of the monitor, which can be used to select the monitors a Probe considers. Monitors of
+// the process itself rather than its dependencies should be tagged with InternalTag.
+func WithTags(tags ...string) MonitorOption {
+	return func(monitor *Monitor) {
+		monitor.tags = tags
+	}
+}
+
+// WithGracePeriod configures how long after being registered a failing monitor is reported as pending rather than
+// failing, unless it has been OK since. Pending monitors do not count towards the overall health, nor cause
+// transition events. A monitor is always pending until its first check completes. Likewise, the checks of the
+// monitor are not published on its status channel until it has been OK or the grace period since it was constructed
+// is over.
+func WithGracePeriod(gracePeriod time.Duration) MonitorOption {
+	return func(monitor *Monitor) {
+		monitor.gracePeriod = gracePeriod
+	}
+}
+
+// WithClock configures the Clock used to time the checks of the monitor. The Clock is carried by the context of its
+// checks, unless the context already carries one, such as that of a BasicDependencySet; see clock.FromContext. If not
+// provided, clock.System is used.
+func WithClock(c clock.Clock) MonitorOption {
+	return func(monitor *Monitor) {
+		monitor.clock = c
+	}
+}
@@ -37,3 +37,67 @@ func TestMonitor_Check_Channel(t *testing.T) {
 	require.Equal(t, status.Next.Status, OUTAGE)
 	wg.Wait()
 }
+
+func sequenceChecker(statuses ...Status) HealthChecker {
+	var i int
+	return func(ctx context.Context) Health {
+		status := statuses[i]
+		i++
+		return NewHealth(status, status.String())
+	}
+}
+
+func TestMonitor_Check_FailureThreshold(t *testing.T) {
+	monitor := NewMonitorWithOptions("test", "", "", REQUIRED,
+		sequenceChecker(OUTAGE, OK, OUTAGE, MAJOR, OUTAGE),
+		WithFailureThreshold(3),
+	)
+
+	health := monitor.Check(context.Background())
+	require.Equal(t, OK, health.Status)
+	require.Equal(t, Message("OUTAGE, 1 of 3 consecutive failures"), health.Message)
+	require.Equal(t, OUTAGE, health.observed.Status)
+
+	// an OK check resets the count
+	health = monitor.Check(context.Background())
+	require.Equal(t, OK, health.Status)
+	require.Nil(t, health.observed)
+
+	require.Equal(t, OK, monitor.Check(context.Background()).Status)
+	require.Equal(t, OK, monitor.Check(context.Background()).Status)
+	require.Equal(t, 2, monitor.Failed())
+
+	health = monitor.Check(context.Background())
+	require.Equal(t, OUTAGE, health.Status)
+	require.Nil(t, health.observed)
+}
+
+func TestMonitor_Check_RecoveryThreshold(t *testing.T) {
+	statusChan := make(chan HealthStatus, 5)
+	monitor := NewMonitorWithOptions("test", "", "", REQUIRED,
+		sequenceChecker(MAJOR, OK, OK, OK),
+		WithRecoveryThreshold(3),
+		WithStatusChan(statusChan),
+	)
+
+	require.Equal(t, MAJOR, monitor.Check(context.Background()).Status)
+
+	health := monitor.Check(context.Background())
+	require.Equal(t, MAJOR, health.Status)
+	require.Equal(t, Message("OK, 1 of 3 consecutive successes"), health.Message)
+	require.Equal(t, OK, health.observed.Status)
+	require.Equal(t, 0, monitor.Failed())
+
+	require.Equal(t, MAJOR, monitor.Check(context.Background()).Status)
+	require.Equal(t, OK, monitor.Check(context.Background()).Status)
+
+	// the status channel only sees the reported transitions
+	var transitions []Status
+	for len(statusChan) > 0 {
+		status := <-statusChan
+		if status.Prev != status.Next.Status {
+			transitions = append(transitions, status.Next.Status)
+		}
+	}
+	require.Equal(t, []Status{MAJOR, OK}, transitions)
+}
@@ -25,3 +25,16 @@ func WrapServeMux(
 	mux.Handle(PrivateHealthCheck, privHandler)
 	mux.Handle(PrivateHealthCheckLive, privHandler)
 }
+
+// WrapServeMuxAdmin will wrap mux with the administrative Handlers of set for
+//   - /private/healthcheck/live/{id}
+//   - /private/healthcheck/history, if set is configured WithHistory
+//
+// These are not registered by WrapServeMux, so that applications which
+// already serve any of these paths keep working.
+func WrapServeMuxAdmin(mux *http.ServeMux, set *BasicDependencySet) {
+	mux.Handle(PrivateHealthCheckTrigger, NewTrigger(set))
+	if set.historySize > 0 {
+		mux.Handle(PrivateHealthCheckHistory, NewHistoryHandler(set))
+	}
+}
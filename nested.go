@@ -0,0 +1,52 @@
+package libhealth
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// NestedMonitor creates a Monitor of a whole DependencySet, such as the one
+// of a subsystem of the application. The health of the Monitor is the Overall
+// Status of the Background Summary of set, which is then capped by urgency as
+// usual. The components of set are nested under the Monitor in the private
+// healthcheck.
+func NestedMonitor(
+	set DependencySet,
+	name,
+	description,
+	docURL string,
+	urgency Urgency,
+	options ...MonitorOption,
+) *Monitor {
+	return NewMonitorWithOptions(
+		name,
+		description,
+		docURL,
+		urgency,
+		func(ctx context.Context) Health {
+			summary := set.Background()
+			h := NewHealth(summary.Overall(), nestedMessage(summary))
+			h.nested = &summary
+			return h
+		},
+		options...,
+	)
+}
+
+// nestedMessage lists the components of s which are not OK.
+func nestedMessage(s Summary) string {
+	failing := make([]string, 0, len(s.results))
+	for _, result := range s.results {
+		if !result.pending && !result.SameAs(OK) {
+			failing = append(failing, fmt.Sprintf("%s is %s", result.name, result.Status))
+		}
+	}
+
+	if len(failing) == 0 {
+		return "all components are OK"
+	}
+	sort.Strings(failing)
+	return strings.Join(failing, ", ")
+}
@@ -0,0 +1,49 @@
+package libhealth
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestNestedMonitor(t *testing.T) {
+	reporting := NewBasicDependencySet(
+		NewMonitor("reporting-db", "", "", REQUIRED, func(ctx context.Context) Health {
+			return NewHealth(OUTAGE, "unreachable")
+		}, nil),
+		NewMonitor("reporting-cache", "", "", REQUIRED, func(ctx context.Context) Health {
+			return NewHealth(OK, "okay")
+		}, nil),
+	)
+	defer reporting.Close()
+	reporting.waitUntilInitialRun()
+
+	deps := NewBasicDependencySet(NestedMonitor(reporting, "reporting", "the reporting subsystem", "", WEAK))
+	defer deps.Close()
+	deps.waitUntilInitialRun()
+
+	// the outage of the subsystem is capped by its urgency
+	summary := deps.Background()
+	require.Equal(t, MINOR, summary.Overall())
+	require.Equal(t, Message("reporting-db is OUTAGE"), summary.results[0].Message)
+
+	raw, _ := NewPrivate("test_nested", deps).generate(false, "test_nested")
+	var result PrivateResult
+	require.NoError(t, json.Unmarshal(raw, &result))
+	require.Len(t, result.Results.Minor, 1)
+
+	nested := result.Results.Minor[0].Components
+	require.NotNil(t, nested)
+	require.Len(t, nested.Outage, 1)
+	require.Equal(t, "reporting-db", nested.Outage[0].ID)
+	require.Len(t, nested.Ok, 1)
+	require.Equal(t, "reporting-cache", nested.Ok[0].ID)
+}
+
+func Test_nestedMessage(t *testing.T) {
+	require.Equal(t, "all components are OK", nestedMessage(NewSummary(epoch, []Result{
+		{name: "a", Health: Health{Status: OK}},
+	})))
+}
@@ -0,0 +1,278 @@
+package libhealth
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"oss.indeed.com/go/libhealth/clock"
+	"oss.indeed.com/go/libtime"
+)
+
+// PrivateHealthCheckOverride is the endpoint for managing Overrides.
+const PrivateHealthCheckOverride = `/private/healthcheck/override`
+
+// An Override forces the Status reported for a HealthMonitor, for example
+// to pin a WEAK dependency to OK while it is in planned maintenance. The
+// HealthMonitor keeps running its checks, and the private healthcheck shows
+// the actual result alongside the Override.
+type Override struct {
+	Status  Status
+	Reason  string
+	Author  string
+	Expires time.Time // the zero value never expires
+}
+
+func (o Override) expired(now time.Time) bool {
+	return !o.Expires.IsZero() && !now.Before(o.Expires)
+}
+
+// message replaces the message of the Health which o overrides.
+func (o Override) message() string {
+	message := "overridden"
+	if o.Author != "" {
+		message += " by " + o.Author
+	}
+	if o.Reason != "" {
+		message += ": " + o.Reason
+	}
+	return message
+}
+
+// overridden is a Result which was replaced by an Override.
+type overridden struct {
+	Override
+	actual Health
+}
+
+// SetOverride will force the Status reported for the HealthMonitor
+// registered with name until o expires or is cleared. The Status of o is
+// downgraded by the Urgency of the HealthMonitor, the same as the results
+// of its checks. Once o expires, it is cleared.
+func (d *BasicDependencySet) SetOverride(name string, o Override) error {
+	d.lock.Lock()
+	defer d.lock.Unlock()
+
+	if _, exists := d.monitors[name]; !exists {
+		return fmt.Errorf("%w %q", ErrUnknownMonitor, name)
+	}
+	d.clearOverride(name)
+	d.overrides[name] = o
+	if !o.Expires.IsZero() && !d.isClosed() {
+		d.expiries[name] = clock.AfterFunc(d.clock, o.Expires.Sub(d.clock.Now()), func() {
+			d.expireOverride(name, o)
+		})
+	}
+	d.publishTransitions(nil, nil)
+	return nil
+}
+
+// ClearOverride will remove the Override of the HealthMonitor registered
+// with name, if any.
+func (d *BasicDependencySet) ClearOverride(name string) {
+	d.lock.Lock()
+	defer d.lock.Unlock()
+
+	d.clearOverride(name)
+	d.publishTransitions(nil, nil)
+}
+
+// expireOverride clears o once it expires, unless it was replaced since.
+func (d *BasicDependencySet) expireOverride(name string, o Override) {
+	d.lock.Lock()
+	defer d.lock.Unlock()
+
+	if current, exists := d.overrides[name]; !exists || current != o {
+		return
+	}
+	d.clearOverride(name)
+	d.publishTransitions(nil, nil)
+}
+
+// clearOverride must be called while holding lock.
+func (d *BasicDependencySet) clearOverride(name string) {
+	if timer, exists := d.expiries[name]; exists {
+		timer.Stop()
+		delete(d.expiries, name)
+	}
+	delete(d.overrides, name)
+}
+
+// Overrides returns the Overrides which have not yet expired, by the name
+// of their HealthMonitor.
+func (d *BasicDependencySet) Overrides() map[string]Override {
+	d.lock.RLock()
+	defer d.lock.RUnlock()
+
+	now := d.clock.Now()
+	overrides := make(map[string]Override, len(d.overrides))
+	for name, o := range d.overrides {
+		if !o.expired(now) {
+			overrides[name] = o
+		}
+	}
+	return overrides
+}
+
+// overridden replaces the Health of results which have an Override.
+//
+// Must be called while holding lock.
+func (d *BasicDependencySet) overridden(results []Result) []Result {
+	now := d.clock.Now()
+	for i, result := range results {
+		o, exists := d.overrides[result.name]
+		if !exists || o.expired(now) {
+			continue
+		}
+
+		results[i].override = &overridden{Override: o, actual: result.Health}
+		results[i].Status = result.Urgency.DowngradeWith(OK, o.Status)
+		results[i].Message = Message(o.message())
+	}
+	return results
+}
+
+// A ComponentOverride describes the Override of a Component in a
+// /private/healthcheck result, along with the actual result it replaced.
+type ComponentOverride struct {
+	Reason        string `json:"reason"`
+	Author        string `json:"author"`
+	Expires       int64  `json:"expiresTimestamp,omitempty"`
+	ExpiresDate   string `json:"expiresDate,omitempty"`
+	ActualState   string `json:"actualStatus"`
+	ActualMessage string `json:"actualErrorMessage"`
+}
+
+func copyOverride(o *overridden) *ComponentOverride {
+	if o == nil {
+		return nil
+	}
+	c := &ComponentOverride{
+		Reason:        o.Reason,
+		Author:        o.Author,
+		ActualState:   o.actual.Status.String(),
+		ActualMessage: string(o.actual.Message),
+	}
+	if !o.Expires.IsZero() {
+		c.Expires = libtime.ToMilliseconds(o.Expires)
+		c.ExpiresDate = o.Expires.Format(timeFormat)
+	}
+	return c
+}
+
+// OverrideHandler is an http.Handler for
+//
+//	/private/healthcheck/override
+//
+// which manages the Overrides of a BasicDependencySet. GET lists the
+// Overrides, PUT or POST sets one from an OverrideRequest body, and
+// DELETE clears the one of the HealthMonitor named by the id query
+// parameter. Every request must be allowed by the authorize func.
+type OverrideHandler struct {
+	set       *BasicDependencySet
+	authorize func(r *http.Request) bool
+}
+
+// NewOverrideHandler creates a new OverrideHandler for the HealthMonitors of
+// set. Only requests for which authorize returns true are served; if it is
+// nil, none are.
+func NewOverrideHandler(set *BasicDependencySet, authorize func(r *http.Request) bool) *OverrideHandler {
+	return &OverrideHandler{set: set, authorize: authorize}
+}
+
+// An OverrideRequest is the body of a request setting an Override. Expires is
+// formatted as RFC 3339, or instead TTL as a time.Duration, such as "2h".
+type OverrideRequest struct {
+	ID      string `json:"id"`
+	Status  string `json:"status"`
+	Reason  string `json:"reason"`
+	Author  string `json:"author"`
+	Expires string `json:"expires,omitempty"`
+	TTL     string `json:"ttl,omitempty"`
+}
+
+func (req OverrideRequest) override(now time.Time) (Override, error) {
+	o := Override{
+		Reason: req.Reason,
+		Author: req.Author,
+	}
+	status, err := ParseStatusStrict(req.Status)
+	if err != nil {
+		return o, err
+	}
+	o.Status = status
+
+	switch {
+	case req.Expires != "":
+		expires, err := time.Parse(time.RFC3339, req.Expires)
+		if err != nil {
+			return o, fmt.Errorf("invalid expires: %w", err)
+		}
+		o.Expires = expires
+	case req.TTL != "":
+		ttl, err := time.ParseDuration(req.TTL)
+		if err != nil {
+			return o, fmt.Errorf("invalid ttl: %w", err)
+		}
+		o.Expires = now.Add(ttl)
+	}
+	return o, nil
+}
+
+func (h *OverrideHandler) generate(r *http.Request) ([]byte, int) {
+	switch r.Method {
+	case http.MethodGet:
+	case http.MethodPut, http.MethodPost:
+		var req OverrideRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			return errorJSON(err), http.StatusBadRequest
+		}
+		o, err := req.override(h.set.clock.Now())
+		if err != nil {
+			return errorJSON(err), http.StatusBadRequest
+		}
+		if err := h.set.SetOverride(req.ID, o); err != nil {
+			return errorJSON(err), http.StatusNotFound
+		}
+	case http.MethodDelete:
+		h.set.ClearOverride(r.URL.Query().Get("id"))
+	default:
+		return errorJSON(fmt.Errorf("method %s not allowed", r.Method)), http.StatusMethodNotAllowed
+	}
+
+	overrides := make(map[string]OverrideRequest)
+	for name, o := range h.set.Overrides() {
+		req := OverrideRequest{ID: name, Status: o.Status.String(), Reason: o.Reason, Author: o.Author}
+		if !o.Expires.IsZero() {
+			req.Expires = o.Expires.Format(time.RFC3339)
+		}
+		overrides[name] = req
+	}
+	body, err := json.Marshal(overrides)
+	if err != nil {
+		return []byte(privateBad), http.StatusInternalServerError
+	}
+	return body, http.StatusOK
+}
+
+func (h *OverrideHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if h.authorize == nil || !h.authorize(r) {
+		http.Error(w, http.StatusText(http.StatusForbidden), http.StatusForbidden)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	body, code := h.generate(r)
+	var prettyJSON bytes.Buffer
+	if err := json.Indent(&prettyJSON, body, "", "  "); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.WriteHeader(code)
+	if _, err := w.Write(prettyJSON.Bytes()); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+}
@@ -0,0 +1,137 @@
+package libhealth
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"oss.indeed.com/go/libhealth/clock"
+)
+
+func TestBasicDependencySet_SetOverride(t *testing.T) {
+	deps := NewBasicDependencySet(NewMonitorWithOptions("maintained", "", "", WEAK, sequenceChecker(OUTAGE, OUTAGE)))
+	defer deps.Close()
+	deps.waitUntilInitialRun()
+	require.Equal(t, MINOR, deps.Background().Overall())
+
+	require.NoError(t, deps.SetOverride("maintained", Override{
+		Status: OK,
+		Reason: "planned maintenance",
+		Author: "ops",
+	}))
+	require.Error(t, deps.SetOverride("missing", Override{Status: OK}))
+
+	summary := deps.Live()
+	require.Equal(t, OK, summary.Overall())
+
+	components := copyComponents(summary)
+	require.Equal(t, "OK", components[0].State)
+	require.Equal(t, "overridden by ops: planned maintenance", components[0].Message)
+	require.Equal(t, "MINOR", components[0].Override.ActualState)
+	require.Equal(t, "OUTAGE", components[0].Override.ActualMessage)
+
+	deps.ClearOverride("maintained")
+	require.Equal(t, MINOR, deps.Background().Overall())
+	require.Empty(t, deps.Overrides())
+}
+
+func TestBasicDependencySet_SetOverride_expired(t *testing.T) {
+	deps := NewBasicDependencySet(NewMonitorWithOptions("maintained", "", "", REQUIRED, sequenceChecker(OUTAGE)))
+	defer deps.Close()
+	deps.waitUntilInitialRun()
+
+	require.NoError(t, deps.SetOverride("maintained", Override{
+		Status:  OK,
+		Expires: time.Now().Add(-time.Second),
+	}))
+	require.Equal(t, OUTAGE, deps.Background().Overall())
+	require.Empty(t, deps.Overrides())
+}
+
+func Test_OverrideHandler_ServeHTTP(t *testing.T) {
+	deps := NewBasicDependencySet(NewMonitorWithOptions("maintained", "", "", WEAK, sequenceChecker(OUTAGE)))
+	defer deps.Close()
+	deps.waitUntilInitialRun()
+
+	handler := NewOverrideHandler(deps, func(r *http.Request) bool {
+		return r.Header.Get("X-Admin") == "yes"
+	})
+	serve := func(method, target, body string, admin bool) *httptest.ResponseRecorder {
+		r := httptest.NewRequest(method, target, strings.NewReader(body))
+		if admin {
+			r.Header.Set("X-Admin", "yes")
+		}
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, r)
+		return w
+	}
+
+	const body = `{"id":"maintained","status":"OK","reason":"upgrade","author":"ops","ttl":"1h"}`
+	require.Equal(t, http.StatusForbidden, serve(http.MethodPut, PrivateHealthCheckOverride, body, false).Code)
+	require.Empty(t, deps.Overrides())
+
+	w := serve(http.MethodPut, PrivateHealthCheckOverride, body, true)
+	require.Equal(t, http.StatusOK, w.Code)
+	var overrides map[string]OverrideRequest
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &overrides))
+	require.Equal(t, "upgrade", overrides["maintained"].Reason)
+	require.Equal(t, OK, deps.Background().Overall())
+
+	require.Equal(t, http.StatusBadRequest,
+		serve(http.MethodPut, PrivateHealthCheckOverride, `{"id":"maintained","status":"FINE"}`, true).Code)
+	require.Equal(t, http.StatusNotFound,
+		serve(http.MethodPut, PrivateHealthCheckOverride, `{"id":"missing","status":"OK"}`, true).Code)
+
+	require.Equal(t, http.StatusOK, serve(http.MethodDelete, PrivateHealthCheckOverride+"?id=maintained", "", true).Code)
+	require.Empty(t, deps.Overrides())
+
+	w = httptest.NewRecorder()
+	NewOverrideHandler(deps, nil).ServeHTTP(w, httptest.NewRequest(http.MethodGet, PrivateHealthCheckOverride, nil))
+	require.Equal(t, http.StatusForbidden, w.Code)
+}
+
+func TestBasicDependencySet_SetOverride_expires(t *testing.T) {
+	fake := clock.NewFake(fakeEpoch)
+	deps := NewBasicDependencySetWithOptions(context.Background(), WithSetClock(fake))
+	defer deps.Close()
+	deps.Register(NewMonitorWithOptions("maintained", "", "", REQUIRED, sequenceChecker(OUTAGE), WithPeriod(0)))
+	deps.waitUntilInitialRun()
+	s := deps.Subscribe()
+	defer s.Close()
+
+	require.NoError(t, deps.SetOverride("maintained", Override{
+		Status:  OK,
+		Expires: fakeEpoch.Add(time.Minute),
+	}))
+	require.Equal(t, OK, receive(t, s).Next)
+	require.Equal(t, "overridden", copyComponents(deps.Background())[0].Message)
+
+	// the expiry is published without another check
+	fake.Advance(time.Minute)
+	e := receive(t, s)
+	require.Equal(t, OverallTransition, e.Kind)
+	require.Equal(t, OUTAGE, e.Next)
+	require.Empty(t, deps.Overrides())
+	require.Zero(t, fake.Timers())
+}
+
+func TestBasicDependencySet_SetOverride_replaced(t *testing.T) {
+	fake := clock.NewFake(fakeEpoch)
+	deps := NewBasicDependencySetWithOptions(context.Background(), WithSetClock(fake))
+	defer deps.Close()
+	deps.Register(NewMonitorWithOptions("maintained", "", "", REQUIRED, sequenceChecker(OUTAGE), WithPeriod(0)))
+	deps.waitUntilInitialRun()
+
+	require.NoError(t, deps.SetOverride("maintained", Override{Status: OK, Expires: fakeEpoch.Add(time.Minute)}))
+	require.NoError(t, deps.SetOverride("maintained", Override{Status: OK, Author: "ops"}))
+	require.Zero(t, fake.Timers(), "the expiry of a replaced Override is stopped")
+
+	fake.Advance(time.Hour)
+	require.Equal(t, "ops", deps.Overrides()["maintained"].Author)
+}
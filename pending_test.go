@@ -0,0 +1,112 @@
+package libhealth
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"oss.indeed.com/go/libhealth/clock"
+)
+
+func TestBasicDependencySet_pending(t *testing.T) {
+	started := make(chan struct{})
+	release := make(chan struct{})
+	deps := NewBasicDependencySet(NewMonitor("slow", "", "", REQUIRED, func(ctx context.Context) Health {
+		close(started)
+		<-release
+		return NewHealth(OUTAGE, "down")
+	}, nil))
+	defer deps.Close()
+	<-started
+
+	summary := deps.Background()
+	require.Equal(t, OK, summary.Overall(), "a monitor which has not run yet is not counted")
+	components := categorize(copyComponents(summary))
+	require.Len(t, components.Pending, 1)
+	require.Equal(t, Pending, components.Pending[0].State)
+	require.Equal(t, "healthcheck has not run yet", components.Pending[0].Message)
+
+	close(release)
+	deps.waitUntilInitialRun()
+	require.Equal(t, OUTAGE, deps.Background().Overall())
+}
+
+func TestBasicDependencySet_WithGracePeriod(t *testing.T) {
+	deps := NewBasicDependencySet()
+	defer deps.Close()
+	s := deps.Subscribe()
+	defer s.Close()
+
+	deps.Register(NewMonitorWithOptions("warming", "", "", REQUIRED,
+		sequenceChecker(OUTAGE, OK, OUTAGE),
+		WithGracePeriod(time.Hour),
+	))
+	deps.waitUntilInitialRun()
+
+	// failing during the grace period is pending
+	summary := deps.Background()
+	require.Equal(t, OK, summary.Overall())
+	require.True(t, summary.results[0].pending)
+	require.Equal(t, OUTAGE, summary.results[0].Status)
+	require.Len(t, categorize(copyComponents(summary)).Pending, 1)
+
+	// once OK, failing counts even during the grace period
+	require.Equal(t, OK, deps.Live().Overall())
+	require.Equal(t, OUTAGE, deps.Live().Overall())
+
+	e := receive(t, s)
+	require.Equal(t, MonitorTransition, e.Kind)
+	require.Equal(t, OK, e.Prev)
+	require.Equal(t, OUTAGE, e.Next)
+}
+
+func TestBasicDependencySet_WithGracePeriod_expired(t *testing.T) {
+	deps := NewBasicDependencySet(NewMonitorWithOptions("warming", "", "", REQUIRED,
+		sequenceChecker(OUTAGE),
+		WithGracePeriod(time.Nanosecond),
+	))
+	defer deps.Close()
+	deps.waitUntilInitialRun()
+
+	require.Equal(t, OUTAGE, deps.Background().Overall())
+}
+
+func TestMonitor_WithGracePeriod_statusChan(t *testing.T) {
+	fake := clock.NewFake(time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC))
+	statusChan := make(chan HealthStatus, 4)
+	monitor := NewMonitorWithOptions("warming", "", "", REQUIRED,
+		sequenceChecker(OUTAGE, OUTAGE, MAJOR),
+		WithStatusChan(statusChan),
+		WithGracePeriod(time.Minute),
+		WithClock(fake),
+	)
+
+	// failing during the grace period is not published
+	monitor.Check(context.Background())
+	require.Len(t, statusChan, 0)
+
+	fake.Advance(time.Minute)
+	monitor.Check(context.Background())
+	status := <-statusChan
+	require.Equal(t, OK, status.Prev)
+	require.Equal(t, OUTAGE, status.Next.Status)
+
+	monitor.Check(context.Background())
+	status = <-statusChan
+	require.Equal(t, OUTAGE, status.Prev)
+	require.Equal(t, MAJOR, status.Next.Status)
+}
+
+func Test_Summary_Status_pending(t *testing.T) {
+	summary := NewSummary(time.Now(), []Result{
+		{name: "warming", pending: true, Health: Health{Status: OUTAGE, Urgency: REQUIRED}},
+		{name: "db", Health: Health{Status: MAJOR, Urgency: REQUIRED}},
+	})
+
+	require.Equal(t, OK, summary.Status("warming"))
+	require.Equal(t, OK, summary.StatusWithUrgency("warming"))
+	require.Equal(t, MAJOR, summary.Status("warming", "db"))
+	require.Equal(t, MAJOR, summary.StatusWithUrgency("warming", "db"))
+	require.Equal(t, MAJOR, summary.Overall())
+}
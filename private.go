@@ -3,6 +3,7 @@ package libhealth
 import (
 	"bytes"
 	"encoding/json"
+	"fmt"
 	"net/http"
 	"os"
 	"strconv"
@@ -30,6 +31,7 @@ var allowlistEnv = []string{"HOME", "LANG", "PATH", "PWD", "TMPDIR", "SHELL", "U
 //	/private/healthcheck
 //	/private/healthcheck/live
 type Private struct {
+	handlerConfig
 	dependencies DependencySet
 	appName      string
 	startTime    time.Time
@@ -37,37 +39,53 @@ type Private struct {
 
 // NewPrivate creates a new Private so that service appName can
 // be register its DependencySet.
-func NewPrivate(appName string, set DependencySet) *Private {
+func NewPrivate(appName string, set DependencySet, options ...HandlerOption) *Private {
+	config := newHandlerConfig(PrivateStatusCodes, options)
 	return &Private{
-		dependencies: set,
-		appName:      appName,
-		startTime:    time.Now(),
+		handlerConfig: config,
+		dependencies:  set,
+		appName:       appName,
+		startTime:     config.clock.Now(),
 	}
 }
 
 // Components of a particular Status. We list them explicitly
 // so that during json encoding they are ordered.
 type Components struct {
-	Outage []Component `json:"OUTAGE,omitempty"`
-	Major  []Component `json:"MAJOR,omitempty"`
-	Minor  []Component `json:"MINOR,omitempty"`
-	Ok     []Component `json:"OK,omitempty"`
+	Outage  []Component `json:"OUTAGE,omitempty"`
+	Major   []Component `json:"MAJOR,omitempty"`
+	Minor   []Component `json:"MINOR,omitempty"`
+	Ok      []Component `json:"OK,omitempty"`
+	Pending []Component `json:"PENDING,omitempty"`
 }
 
+// Pending is the status of a component in a /private/healthcheck result
+// which is not counted yet, see WithGracePeriod.
+const Pending = "PENDING"
+
 // A Component is the healthcheck status of one
 // component in a /private/healthcheck result.
 type Component struct {
-	Timestamp   int64  `json:"timestamp"`
-	DocURL      string `json:"documentationUrl"`
-	Urgency     string `json:"urgency"`
-	Description string `json:"description"`
-	State       string `json:"status"`
-	Message     string `json:"errorMessage"`
-	Duration    int64  `json:"duration"`
-	LastGood    int64  `json:"lastKnownGoodTimestamp"`
-	Period      int64  `json:"period"`
-	ID          string `json:"id"`
-	Date        string `json:"date"`
+	Timestamp       int64              `json:"timestamp"`
+	DocURL          string             `json:"documentationUrl"`
+	Urgency         string             `json:"urgency"`
+	Description     string             `json:"description"`
+	State           string             `json:"status"`
+	Message         string             `json:"errorMessage"`
+	Duration        int64              `json:"duration"`
+	LastGood        int64              `json:"lastKnownGoodTimestamp"`
+	Period          int64              `json:"period"`
+	ID              string             `json:"id"`
+	Date            string             `json:"date"`
+	ObservedState   string             `json:"observedStatus,omitempty"`
+	ObservedMessage string             `json:"observedErrorMessage,omitempty"`
+	Panics          int                `json:"panics,omitempty"`
+	PanicStack      string             `json:"panicStack,omitempty"`
+	BlockedBy       []string           `json:"blockedBy,omitempty"`
+	Components      *Components        `json:"components,omitempty"`
+	Details         Details            `json:"details,omitempty"`
+	Error           string             `json:"error,omitempty"`
+	Override        *ComponentOverride `json:"override,omitempty"`
 }
 
 // A PrivateResult is the struct (and JSON) definition of what
@@ -93,6 +111,10 @@ type PrivateResult struct {
 func categorize(results []Component) Components {
 	c := Components{}
 	for _, result := range results {
+		if result.State == Pending {
+			c.Pending = append(c.Pending, result)
+			continue
+		}
 		switch ParseStatus(result.State) {
 		case OUTAGE:
 			c.Outage = append(c.Outage, result)
@@ -110,7 +132,7 @@ func categorize(results []Component) Components {
 func copyComponents(s Summary) []Component {
 	components := make([]Component, 0, len(s.results))
 	for _, result := range s.results {
-		components = append(components, Component{
+		component := Component{
 			Timestamp:   libtime.ToMilliseconds(result.Time),
 			DocURL:      result.docurl,
 			Urgency:     result.Urgency.Detail(),
@@ -122,11 +144,58 @@ func copyComponents(s Summary) []Component {
 			Period:      result.period.Nanoseconds() / 1000000000,
 			ID:          result.name,
 			Date:        result.Time.Format(timeFormat),
-		})
+			Panics:      result.panics,
+			PanicStack:  result.stack,
+			BlockedBy:   result.blockedBy,
+			Details:     encodable(result.Details),
+			Override:    copyOverride(result.override),
+		}
+		if result.pending {
+			component.State = Pending
+		}
+		if result.Err != nil {
+			component.Error = result.Err.Error()
+		}
+		if nested := result.nested; nested != nil {
+			components := categorize(copyComponents(*nested))
+			component.Components = &components
+		}
+		if len(result.blockedBy) > 0 {
+			root := result.blockedBy[len(result.blockedBy)-1]
+			component.Message = "blocked by " + root + ": " + component.Message
+		}
+		if observed := result.observed; observed != nil {
+			component.ObservedState = observed.Status.String()
+			component.ObservedMessage = string(observed.Message)
+		}
+		components = append(components, component)
 	}
 	return components
 }
 
+// encodable returns details, with every value which cannot be encoded as
+// JSON, such as NaN, replaced by its string representation, so that one
+// check cannot break the whole response.
+func encodable(details Details) Details {
+	var replaced Details
+	for key, value := range details {
+		if _, err := json.Marshal(value); err == nil {
+			continue
+		}
+		if replaced == nil {
+			replaced = make(Details, len(details))
+			for k, v := range details {
+				replaced[k] = v
+			}
+		}
+		replaced[key] = fmt.Sprintf("%v", value)
+	}
+	if replaced == nil {
+		return details
+	}
+	return replaced
+}
+
 func (p *Private) generate(live bool, hostname string) (hc []byte, code int) {
 	var summary Summary
 	if live {
@@ -138,7 +207,7 @@ func (p *Private) generate(live bool, hostname string) (hc []byte, code int) {
 	components := copyComponents(summary)
 	categorized := categorize(components)
 
-	appTimes := times(time.Now(), p.startTime)
+	appTimes := times(p.clock.Now(), p.startTime)
 	cwd, _ := os.Getwd()
 	env := os.Environ()
 	envMap := make(map[string]string, len(env))
@@ -150,7 +219,7 @@ func (p *Private) generate(live bool, hostname string) (hc []byte, code int) {
 
 	result := PrivateResult{
 		AppName:                   p.appName,
-		Condition:                 summary.Overall().String(),
+		Condition:                 summary.condition(),
 		Hostname:                  hostname,
 		Environment:               envMap,
 		CWD:                       cwd,
@@ -168,7 +237,7 @@ func (p *Private) generate(live bool, hostname string) (hc []byte, code int) {
 	if err != nil {
 		return []byte(privateBad), http.StatusInternalServerError
 	}
-	return hc, ComputeStatusCode(false, summary)
+	return hc, p.statusCodePolicy.code(summary)
 }
 
 type timeCollection struct {
@@ -3,6 +3,8 @@ package libhealth
 import (
 	"context"
 	"encoding/json"
+	"errors"
+	"math"
 	"net/http"
 	"testing"
 	"time"
@@ -297,3 +299,62 @@ type privateDep struct {
 	Status  string `json:"status"`
 	Urgency string `json:"urgency"`
 }
+
+func Test_copyComponents_observed(t *testing.T) {
+	observed := Health{Status: OUTAGE, Urgency: WEAK, Message: "the thing is broken"}
+	summary := NewSummary(time.Now(), []Result{
+		wrap(NewMonitor("check1", "", "", WEAK, nil, nil), Health{
+			Status:   OK,
+			Urgency:  WEAK,
+			Message:  "the thing is broken, 1 of 2 consecutive failures",
+			observed: &observed,
+		}),
+	})
+
+	components := copyComponents(summary)
+	require.Equal(t, "OK", components[0].State)
+	require.Equal(t, "MINOR", components[0].ObservedState)
+	require.Equal(t, "the thing is broken", components[0].ObservedMessage)
+}
+
+func Test_Private_details(t *testing.T) {
+	deps := NewBasicDependencySet(NewMonitor(
+		"replication",
+		"checks replication lag",
+		"http://example.com/wiki/Replication",
+		STRONG,
+		func(ctx context.Context) Health {
+			return NewHealth(MAJOR, "lagging").
+				With("lag_ms", 1200).
+				WithError(errors.New("replica is behind"))
+		}, nil))
+	defer deps.Close()
+	deps.waitUntilInitialRun()
+
+	raw, _ := NewPrivate("test_details", deps).generate(false, "test_details")
+	var result PrivateResult
+	require.NoError(t, json.Unmarshal(raw, &result))
+	require.Len(t, result.Results.Major, 1)
+	require.Equal(t, Details{"lag_ms": 1200.0}, result.Results.Major[0].Details)
+	require.Equal(t, "replica is behind", result.Results.Major[0].Error)
+}
+
+func Test_Private_Details_unencodable(t *testing.T) {
+	deps := NewBasicDependencySet(
+		NewMonitor("pool", "", "", WEAK, func(ctx context.Context) Health {
+			return NewHealth(MINOR, "exhausted").With("pool_used", math.NaN()).With("size", 0)
+		}, nil),
+		NewMonitor("other", "", "", REQUIRED, func(ctx context.Context) Health {
+			return NewHealth(OK, "okay")
+		}, nil),
+	)
+	defer deps.Close()
+	deps.waitUntilInitialRun()
+
+	raw, code := NewPrivate("test_details", deps).generate(false, "test_details")
+	require.Equal(t, http.StatusInternalServerError, code, "the MINOR status, not a failure to encode")
+	var result PrivateResult
+	require.NoError(t, json.Unmarshal(raw, &result))
+	require.Len(t, result.Results.Ok, 1)
+	require.Equal(t, Details{"pool_used": "NaN", "size": 0.0}, result.Results.Minor[0].Details)
+}
@@ -0,0 +1,166 @@
+package libhealth
+
+import (
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+)
+
+// Paths to Kubernetes style probes.
+const (
+	Livez    = `/livez`
+	Readyz   = `/readyz`
+	Startupz = `/startupz`
+)
+
+// InternalTag is the tag of HealthMonitors which check the process itself,
+// rather than its dependencies. See WithTags.
+const InternalTag = "internal"
+
+// A Selector chooses which Results a Probe considers.
+type Selector func(r Result) bool
+
+// ByUrgency selects the Results of HealthMonitors with any of urgencies.
+func ByUrgency(urgencies ...Urgency) Selector {
+	return func(r Result) bool {
+		for _, urgency := range urgencies {
+			if r.Urgency == urgency {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+// ByTag selects the Results of HealthMonitors with any of tags.
+func ByTag(tags ...string) Selector {
+	return func(r Result) bool {
+		for _, tag := range tags {
+			for _, has := range r.tags {
+				if tag == has {
+					return true
+				}
+			}
+		}
+		return false
+	}
+}
+
+func all(Result) bool {
+	return true
+}
+
+// Probe is an http.Handler for a Kubernetes style probe of a DependencySet,
+// such as
+//
+//	/livez
+//	/readyz
+//	/startupz
+//
+// It responds with HTTP 200 ok when the probe passes, and HTTP 503 service
+// unavailable otherwise, along with a line for each of the selected
+// HealthMonitors.
+type Probe struct {
+	deps     DependencySet
+	name     string
+	selector Selector
+	failed   func(r Result) bool
+	draining bool // whether the probe fails while draining
+}
+
+// NewLiveness creates a liveness Probe, which fails while any of the
+// HealthMonitors chosen by selector are MAJOR or worse after being downgraded
+// by urgency, ignoring pending ones. If selector is nil, the HealthMonitors tagged with InternalTag
+// are chosen, so that failing dependencies do not get the process restarted.
+func NewLiveness(deps DependencySet, selector Selector) *Probe {
+	if selector == nil {
+		selector = ByTag(InternalTag)
+	}
+	return &Probe{deps: deps, name: "livez", selector: selector, failed: failing}
+}
+
+// NewReadiness creates a readiness Probe, which fails while any of the
+// HealthMonitors chosen by selector are pending, or MAJOR or worse after being
+// downgraded by urgency, and while deps is draining. If selector is nil, the REQUIRED
+// and STRONG HealthMonitors are chosen.
+func NewReadiness(deps DependencySet, selector Selector) *Probe {
+	if selector == nil {
+		selector = ByUrgency(REQUIRED, STRONG)
+	}
+	return &Probe{deps: deps, name: "readyz", selector: selector, failed: unready, draining: true}
+}
+
+// NewStartup creates a startup Probe, which fails until every one of the
+// HealthMonitors chosen by selector has completed its first check. If
+// selector is nil, every HealthMonitor is chosen.
+func NewStartup(deps DependencySet, selector Selector) *Probe {
+	if selector == nil {
+		selector = all
+	}
+	return &Probe{deps: deps, name: "startupz", selector: selector, failed: notStarted}
+}
+
+func failing(r Result) bool {
+	return !r.pending && r.SameOrWorseThan(MAJOR)
+}
+
+func unready(r Result) bool {
+	return r.pending || r.SameOrWorseThan(MAJOR)
+}
+
+func notStarted(r Result) bool {
+	return r.initial
+}
+
+func (p *Probe) generate() ([]byte, int) {
+	summary := p.deps.Background()
+
+	lines := make([]string, 0, len(summary.results))
+	passed := true
+	for _, result := range summary.results {
+		if !p.selector(result) {
+			continue
+		}
+		if p.failed(result) {
+			passed = false
+			lines = append(lines, fmt.Sprintf("[-]%s failed: %s", result.name, result.Message))
+		} else {
+			lines = append(lines, fmt.Sprintf("[+]%s ok", result.name))
+		}
+	}
+	sort.Strings(lines)
+
+	if p.draining && summary.draining {
+		passed = false
+		lines = append(lines, "[-]"+Draining)
+	}
+
+	if !passed {
+		lines = append(lines, p.name+" check failed")
+		return []byte(strings.Join(lines, "\n") + "\n"), http.StatusServiceUnavailable
+	}
+	lines = append(lines, p.name+" check passed")
+	return []byte(strings.Join(lines, "\n") + "\n"), http.StatusOK
+}
+
+func (p *Probe) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+	body, code := p.generate()
+	w.WriteHeader(code)
+	if _, err := w.Write(body); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+}
+
+// WrapServeMuxProbes will wrap mux with the default Probes for
+//   - /livez
+//   - /readyz
+//   - /startupz
+func WrapServeMuxProbes(mux *http.ServeMux, provided DependencySet) {
+	mux.Handle(Livez, NewLiveness(provided, nil))
+	mux.Handle(Readyz, NewReadiness(provided, nil))
+	mux.Handle(Startupz, NewStartup(provided, nil))
+}
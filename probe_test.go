@@ -0,0 +1,86 @@
+package libhealth
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func probe(t *testing.T, handler http.Handler) (int, string) {
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/", nil))
+	return w.Code, w.Body.String()
+}
+
+func TestProbe_liveness(t *testing.T) {
+	deps := NewBasicDependencySet(
+		NewMonitorWithOptions("process", "", "", REQUIRED, func(ctx context.Context) Health {
+			return NewHealth(OK, "okay")
+		}, WithTags(InternalTag)),
+		NewMonitor("database", "", "", REQUIRED, func(ctx context.Context) Health {
+			return NewHealth(OUTAGE, "down")
+		}, nil),
+	)
+	defer deps.Close()
+	deps.waitUntilInitialRun()
+
+	code, body := probe(t, NewLiveness(deps, nil))
+	require.Equal(t, http.StatusOK, code)
+	require.Equal(t, "[+]process ok\nlivez check passed\n", body)
+
+	code, body = probe(t, NewReadiness(deps, nil))
+	require.Equal(t, http.StatusServiceUnavailable, code)
+	require.Equal(t, "[+]process ok\n[-]database failed: down\nreadyz check failed\n", body)
+
+	code, _ = probe(t, NewReadiness(deps, ByTag(InternalTag)))
+	require.Equal(t, http.StatusOK, code)
+}
+
+func TestProbe_readiness_urgency(t *testing.T) {
+	deps := NewBasicDependencySet(NewMonitor("cache", "", "", WEAK, func(ctx context.Context) Health {
+		return NewHealth(OUTAGE, "down")
+	}, nil))
+	defer deps.Close()
+	deps.waitUntilInitialRun()
+
+	code, body := probe(t, NewReadiness(deps, nil))
+	require.Equal(t, http.StatusOK, code)
+	require.Equal(t, "readyz check passed\n", body)
+
+	code, _ = probe(t, NewReadiness(deps, ByUrgency(WEAK)))
+	require.Equal(t, http.StatusOK, code) // WEAK is downgraded to MINOR
+
+	deps.Drain()
+	code, body = probe(t, NewReadiness(deps, nil))
+	require.Equal(t, http.StatusServiceUnavailable, code)
+	require.Equal(t, "[-]DRAINING\nreadyz check failed\n", body)
+
+	code, _ = probe(t, NewLiveness(deps, nil))
+	require.Equal(t, http.StatusOK, code)
+}
+
+func TestProbe_startup(t *testing.T) {
+	started := make(chan struct{})
+	release := make(chan struct{})
+	deps := NewBasicDependencySet(NewMonitor("slow", "", "", REQUIRED, func(ctx context.Context) Health {
+		close(started)
+		<-release
+		return NewHealth(OUTAGE, "down")
+	}, nil))
+	defer deps.Close()
+	<-started
+
+	code, body := probe(t, NewStartup(deps, nil))
+	require.Equal(t, http.StatusServiceUnavailable, code)
+	require.Equal(t, "[-]slow failed: healthcheck has not run yet\nstartupz check failed\n", body)
+
+	close(release)
+	deps.waitUntilInitialRun()
+
+	code, body = probe(t, NewStartup(deps, nil))
+	require.Equal(t, http.StatusOK, code)
+	require.Equal(t, "[+]slow ok\nstartupz check passed\n", body)
+}
@@ -0,0 +1,65 @@
+package libhealth
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// WaitReady blocks until every registered HealthMonitor has completed its
+// first check and is no longer pending, and the Overall Status is minStatus
+// or better, as combined by the AggregationPolicy of d. If ctx expires first,
+// an error wrapping ctx.Err() is returned, describing the HealthMonitors which
+// are not ready.
+//
+// WaitReady can be called before serving traffic, so that an application only
+// joins the pool once its dependencies are reachable.
+func (d *BasicDependencySet) WaitReady(ctx context.Context, minStatus Status) error {
+	for {
+		d.lock.RLock()
+		summary := d.summary(d.results())
+		changed := d.changed
+		d.lock.RUnlock()
+
+		if settled(summary) && summary.Overall().SameOrBetterThan(minStatus) {
+			return nil
+		}
+
+		select {
+		case <-changed:
+		case <-ctx.Done():
+			unready := notReady(summary, minStatus)
+			return fmt.Errorf("dependencies not ready: %s: %w", strings.Join(unready, ", "), ctx.Err())
+		}
+	}
+}
+
+// settled returns whether every result of s has completed its first check
+// and is no longer pending.
+func settled(s Summary) bool {
+	for _, result := range s.results {
+		if result.pending {
+			return false
+		}
+	}
+	return true
+}
+
+// notReady describes the results of s which are pending or worse than
+// minStatus, sorted by name.
+func notReady(s Summary, minStatus Status) []string {
+	var unready []string
+	for _, result := range s.results {
+		switch {
+		case result.initial:
+			unready = append(unready, fmt.Sprintf("%s has not run yet", result.name))
+		case result.pending:
+			unready = append(unready, fmt.Sprintf("%s is pending: %s", result.name, result.Message))
+		case len(result.blockedBy) == 0 && result.WorseThan(minStatus):
+			unready = append(unready, fmt.Sprintf("%s is %s: %s", result.name, result.Status, result.Message))
+		}
+	}
+	sort.Strings(unready)
+	return unready
+}
@@ -0,0 +1,93 @@
+package libhealth
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestBasicDependencySet_WaitReady(t *testing.T) {
+	release := make(chan struct{})
+	deps := NewBasicDependencySet(
+		NewMonitor("slow", "", "", REQUIRED, func(ctx context.Context) Health {
+			<-release
+			return NewHealth(OK, "okay")
+		}, nil),
+		NewMonitor("weak", "", "", WEAK, func(ctx context.Context) Health {
+			return NewHealth(OUTAGE, "down")
+		}, nil),
+	)
+	defer deps.Close()
+
+	ready := make(chan error, 1)
+	go func() {
+		ready <- deps.WaitReady(context.Background(), MINOR)
+	}()
+
+	select {
+	case err := <-ready:
+		t.Fatalf("ready before the first check: %v", err)
+	case <-time.After(10 * time.Millisecond):
+	}
+
+	close(release)
+	select {
+	case err := <-ready:
+		require.NoError(t, err)
+	case <-time.After(time.Second):
+		t.Fatal("not ready after the first check")
+	}
+
+	require.Error(t, deps.WaitReady(canceled(), OK))
+}
+
+func TestBasicDependencySet_WaitReady_timeout(t *testing.T) {
+	release := make(chan struct{})
+	deps := NewBasicDependencySet(
+		NewMonitor("database", "", "", REQUIRED, func(ctx context.Context) Health {
+			return NewHealth(OUTAGE, "connection refused")
+		}, nil),
+		NewMonitor("cache", "", "", WEAK, func(ctx context.Context) Health {
+			return NewHealth(OUTAGE, "down")
+		}, nil),
+		NewMonitor("never", "", "", REQUIRED, func(ctx context.Context) Health {
+			<-release
+			return NewHealth(OK, "okay")
+		}, nil),
+	)
+	defer deps.Close()
+	defer close(release)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	err := deps.WaitReady(ctx, OK)
+	require.True(t, errors.Is(err, context.DeadlineExceeded))
+	require.EqualError(t, err, "dependencies not ready: "+
+		"cache is MINOR: down, "+
+		"database is OUTAGE: connection refused, "+
+		"never has not run yet: context deadline exceeded")
+}
+
+func canceled() context.Context {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	return ctx
+}
+
+func TestBasicDependencySet_WaitReady_WithAggregationPolicy(t *testing.T) {
+	deps := NewBasicDependencySetWithOptions(context.Background(),
+		WithAggregationPolicy(Cap(HCv3, STRONG, MINOR)),
+	)
+	defer deps.Close()
+	deps.Register(NewMonitor("search", "", "", STRONG, func(ctx context.Context) Health {
+		return NewHealth(OUTAGE, "down")
+	}, nil))
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	require.NoError(t, deps.WaitReady(ctx, MINOR), "the capped outage is ready enough")
+}
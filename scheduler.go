@@ -0,0 +1,146 @@
+package libhealth
+
+import (
+	"math"
+	"math/rand"
+	"time"
+)
+
+// A Scheduler decides when a BasicDependencySet runs the background checks
+// of a HealthMonitor. The BasicDependencySet uses the Scheduler of a Monitor
+// configured with WithScheduler, or its own default Scheduler otherwise.
+//
+// HealthMonitors with a Period of 0 are only checked once, after Initial.
+type Scheduler interface {
+	// Initial is how long to wait after monitor is registered before its first check.
+	Initial(monitor HealthMonitor) time.Duration
+	// Next is how long to wait after the start of a check which resulted in last
+	// before the next check of monitor starts.
+	Next(monitor HealthMonitor, last Health) time.Duration
+}
+
+// Fixed is the default Scheduler. It checks a HealthMonitor as soon as it
+// is registered, and then once every Period.
+var Fixed Scheduler = fixed{}
+
+type fixed struct{}
+
+func (fixed) Initial(HealthMonitor) time.Duration {
+	return 0
+}
+
+func (fixed) Next(monitor HealthMonitor, _ Health) time.Duration {
+	return monitor.Period()
+}
+
+// maxJitter is the largest fraction of Jitter, so that delays stay positive.
+const maxJitter = 0.9
+
+// Jitter wraps s so that every delay is randomly lengthened or shortened by
+// up to fraction of itself, so that monitors of hosts which started at the
+// same time drift apart. The fraction is clamped between 0 and 0.9.
+func Jitter(s Scheduler, fraction float64) Scheduler {
+	switch {
+	case !(fraction > 0): // including NaN
+		fraction = 0
+	case fraction > maxJitter:
+		fraction = maxJitter
+	}
+	return &jitter{Scheduler: s, fraction: fraction}
+}
+
+type jitter struct {
+	Scheduler
+	fraction float64
+}
+
+func (j *jitter) Initial(monitor HealthMonitor) time.Duration {
+	return j.spread(j.Scheduler.Initial(monitor))
+}
+
+func (j *jitter) Next(monitor HealthMonitor, last Health) time.Duration {
+	return j.spread(j.Scheduler.Next(monitor, last))
+}
+
+func (j *jitter) spread(delay time.Duration) time.Duration {
+	offset := (2*rand.Float64() - 1) * j.fraction * float64(delay) //nolint:gosec // jitter need not be secure
+	return delay + time.Duration(offset)
+}
+
+// Splay wraps s so that the first check of a HealthMonitor is delayed by a
+// random amount of time up to its Period, so that monitors registered at the
+// same time do not check at the same time.
+func Splay(s Scheduler) Scheduler {
+	return &splay{Scheduler: s}
+}
+
+type splay struct {
+	Scheduler
+}
+
+func (s *splay) Initial(monitor HealthMonitor) time.Duration {
+	initial := s.Scheduler.Initial(monitor)
+	if monitor.Period() <= 0 {
+		return initial
+	}
+	return initial + time.Duration(rand.Int63n(int64(monitor.Period()))) //nolint:gosec // splay need not be secure
+}
+
+// Backoff wraps s so that while a HealthMonitor is failing, the delay until
+// its next check is multiplied by factor for every consecutive failure, up to
+// a delay of limit. A factor below 1 is treated as 1, so that delays never
+// shrink, and a limit of 0 or less means there is no limit.
+func Backoff(s Scheduler, factor float64, limit time.Duration) Scheduler {
+	if !(factor >= 1) { // including NaN
+		factor = 1
+	}
+	if limit <= 0 {
+		limit = math.MaxInt64
+	}
+	return &backoff{Scheduler: s, factor: factor, limit: limit}
+}
+
+type backoff struct {
+	Scheduler
+	factor float64
+	limit  time.Duration
+}
+
+func (b *backoff) Next(monitor HealthMonitor, last Health) time.Duration {
+	next := b.Scheduler.Next(monitor, last)
+	failed := monitor.Failed()
+	if failed == 0 {
+		return next
+	}
+
+	backedOff := float64(next) * math.Pow(b.factor, float64(failed))
+	if backedOff >= float64(b.limit) {
+		return b.limit
+	}
+	return time.Duration(backedOff)
+}
+
+// Recovery wraps s so that while a HealthMonitor is recovering, it is checked
+// again after period. A Monitor is recovering when its checks are OK again,
+// but it is still reporting the failure because of WithRecoveryThreshold.
+// A period of 0 or less has no effect, so that a recovering HealthMonitor is
+// never checked again without delay.
+func Recovery(s Scheduler, period time.Duration) Scheduler {
+	return &recovery{Scheduler: s, period: period}
+}
+
+type recovery struct {
+	Scheduler
+	period time.Duration
+}
+
+func (r *recovery) Next(monitor HealthMonitor, last Health) time.Duration {
+	if r.period > 0 && recovering(last) {
+		return r.period
+	}
+	return r.Scheduler.Next(monitor, last)
+}
+
+func recovering(h Health) bool {
+	return h.observed != nil && h.observed.SameAs(OK) && !h.SameAs(OK)
+}
@@ -0,0 +1,129 @@
+package libhealth
+
+import (
+	"context"
+	"math"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func failingMonitor(failed int, options ...MonitorOption) *Monitor {
+	monitor := NewMonitorWithOptions("failing", "", "", REQUIRED, func(ctx context.Context) Health {
+		return NewHealth(OUTAGE, "outage")
+	}, options...)
+	for i := 0; i < failed; i++ {
+		monitor.Check(context.Background())
+	}
+	return monitor
+}
+
+func TestFixed(t *testing.T) {
+	monitor := failingMonitor(2, WithPeriod(time.Minute))
+	require.Zero(t, Fixed.Initial(monitor))
+	require.Equal(t, time.Minute, Fixed.Next(monitor, NewHealth(OUTAGE, "outage")))
+}
+
+func TestJitter(t *testing.T) {
+	monitor := failingMonitor(0, WithPeriod(time.Minute))
+	scheduler := Jitter(Fixed, 0.1)
+	for i := 0; i < 100; i++ {
+		next := scheduler.Next(monitor, NewHealth(OK, "okay"))
+		require.True(t, next >= 54*time.Second && next <= 66*time.Second, next)
+	}
+}
+
+func TestJitter_clamped(t *testing.T) {
+	monitor := failingMonitor(0, WithPeriod(time.Minute))
+	for i := 0; i < 100; i++ {
+		next := Jitter(Fixed, 5).Next(monitor, NewHealth(OK, "okay"))
+		require.True(t, next >= 6*time.Second && next <= 114*time.Second, next)
+	}
+	require.Equal(t, time.Minute, Jitter(Fixed, -1).Next(monitor, NewHealth(OK, "okay")))
+}
+
+func TestSplay(t *testing.T) {
+	monitor := failingMonitor(0, WithPeriod(time.Minute))
+	scheduler := Splay(Fixed)
+	for i := 0; i < 100; i++ {
+		initial := scheduler.Initial(monitor)
+		require.True(t, initial >= 0 && initial < time.Minute, initial)
+	}
+	require.Equal(t, time.Minute, scheduler.Next(monitor, NewHealth(OK, "okay")))
+
+	require.Zero(t, scheduler.Initial(failingMonitor(0, WithPeriod(0))))
+}
+
+func TestBackoff(t *testing.T) {
+	scheduler := Backoff(Fixed, 2, 5*time.Minute)
+	last := NewHealth(OUTAGE, "outage")
+
+	require.Equal(t, time.Minute, scheduler.Next(failingMonitor(0, WithPeriod(time.Minute)), last))
+	require.Equal(t, 2*time.Minute, scheduler.Next(failingMonitor(1, WithPeriod(time.Minute)), last))
+	require.Equal(t, 4*time.Minute, scheduler.Next(failingMonitor(2, WithPeriod(time.Minute)), last))
+	require.Equal(t, 5*time.Minute, scheduler.Next(failingMonitor(3, WithPeriod(time.Minute)), last))
+}
+
+func TestBackoff_invalid(t *testing.T) {
+	last := NewHealth(OUTAGE, "outage")
+	monitor := failingMonitor(3, WithPeriod(time.Minute))
+
+	require.Equal(t, 8*time.Minute, Backoff(Fixed, 2, 0).Next(monitor, last), "no limit")
+	require.Equal(t, time.Minute, Backoff(Fixed, 0.5, time.Hour).Next(monitor, last), "delays never shrink")
+	require.Equal(t, time.Duration(math.MaxInt64), Backoff(Fixed, 1e300, 0).Next(failingMonitor(10), last))
+}
+
+func TestRecovery(t *testing.T) {
+	monitor := NewMonitorWithOptions("recovering", "", "", REQUIRED,
+		sequenceChecker(OUTAGE, OK),
+		WithPeriod(time.Minute),
+		WithRecoveryThreshold(2),
+	)
+	scheduler := Recovery(Fixed, time.Second)
+
+	require.Equal(t, time.Minute, scheduler.Next(monitor, monitor.Check(context.Background())))
+	require.Equal(t, time.Second, scheduler.Next(monitor, monitor.Check(context.Background())))
+}
+
+func TestRecovery_invalid(t *testing.T) {
+	monitor := NewMonitorWithOptions("recovering", "", "", REQUIRED,
+		sequenceChecker(OUTAGE, OK),
+		WithPeriod(time.Minute),
+		WithRecoveryThreshold(2),
+	)
+	scheduler := Recovery(Fixed, 0)
+
+	monitor.Check(context.Background())
+	require.Equal(t, time.Minute, scheduler.Next(monitor, monitor.Check(context.Background())))
+}
+
+func TestBasicDependencySet_WithScheduler(t *testing.T) {
+	var defaulted, scheduled int32
+	deps := NewBasicDependencySetWithOptions(context.Background(),
+		WithDefaultScheduler(Backoff(Fixed, 1000, time.Hour)),
+	)
+	defer deps.Close()
+
+	failing := func(count *int32) HealthChecker {
+		return func(ctx context.Context) Health {
+			atomic.AddInt32(count, 1)
+			return NewHealth(OUTAGE, "outage")
+		}
+	}
+	deps.Register(
+		NewMonitorWithOptions("defaulted", "", "", REQUIRED, failing(&defaulted),
+			WithPeriod(5*time.Millisecond),
+		),
+		NewMonitorWithOptions("scheduled", "", "", REQUIRED, failing(&scheduled),
+			WithPeriod(5*time.Millisecond),
+			WithScheduler(Fixed),
+		),
+	)
+	deps.waitUntilInitialRun()
+
+	time.Sleep(30 * time.Millisecond)
+	require.Equal(t, int32(1), atomic.LoadInt32(&defaulted), "the default scheduler backs off")
+	require.True(t, atomic.LoadInt32(&scheduled) > 1, "the monitor's own scheduler does not")
+}
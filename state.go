@@ -1,8 +1,27 @@
 package libhealth
 
-import "strings"
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// ErrUnknownStatus is returned when parsing a string which is not the
+// name of a Status.
+var ErrUnknownStatus = errors.New("unknown status")
 
 // Status is a representation of the health of the component.
+//
+// A Status is marshalled as its name, such as "OK", by encoding/json and
+// other encodings which support encoding.TextMarshaler, and can be used as
+// a flag.Value. Its number is still accepted when decoding JSON, so that
+// values encoded before Status was marshalled as text can be read.
+//
+// Because Health embeds Status, Urgency and time.Time alike, their text
+// methods are ambiguous, so Health and Result no longer implement
+// encoding.TextMarshaler or encoding.TextUnmarshaler through time.Time.
 type Status int
 
 const (
@@ -29,6 +48,58 @@ func ParseStatus(state string) Status {
 	}
 }
 
+// ParseStatusStrict parses the given string into a Status, ignoring case.
+// If the string is not the name of a Status, an error wrapping
+// ErrUnknownStatus is returned.
+func ParseStatusStrict(state string) (Status, error) {
+	s := ParseStatus(state)
+	if !strings.EqualFold(s.String(), state) {
+		return OUTAGE, fmt.Errorf("%w %q", ErrUnknownStatus, state)
+	}
+	return s, nil
+}
+
+// MarshalText returns the name of s, or an error if s is not valid.
+func (s Status) MarshalText() ([]byte, error) {
+	if s < OUTAGE || s > OK {
+		return nil, fmt.Errorf("%w %d", ErrUnknownStatus, int(s))
+	}
+	return []byte(s.String()), nil
+}
+
+// UnmarshalText parses text as with ParseStatusStrict.
+func (s *Status) UnmarshalText(text []byte) error {
+	parsed, err := ParseStatusStrict(string(text))
+	if err != nil {
+		return err
+	}
+	*s = parsed
+	return nil
+}
+
+// UnmarshalJSON parses data as either the name of a Status, as with
+// UnmarshalText, or its number.
+func (s *Status) UnmarshalJSON(data []byte) error {
+	if number, err := strconv.Atoi(string(data)); err == nil {
+		if number < int(OUTAGE) || number > int(OK) {
+			return fmt.Errorf("%w %d", ErrUnknownStatus, number)
+		}
+		*s = Status(number)
+		return nil
+	}
+	var text string
+	if err := json.Unmarshal(data, &text); err != nil {
+		return err
+	}
+	return s.UnmarshalText([]byte(text))
+}
+
+// Set parses value as with ParseStatusStrict, so that a Status
+// can be used as a flag.Value.
+func (s *Status) Set(value string) error {
+	return s.UnmarshalText([]byte(value))
+}
+
 // String provides a regular string representation of a Status.
 func (s Status) String() string {
 	switch s {
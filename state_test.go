@@ -1,6 +1,10 @@
 package libhealth
 
 import (
+	"encoding/json"
+	"errors"
+	"flag"
+	"io/ioutil"
 	"testing"
 
 	"github.com/stretchr/testify/require"
@@ -69,3 +73,47 @@ func Test_WorstState(t *testing.T) {
 	require.Equal(t, MINOR, WorstState(OK, MINOR))
 	require.Equal(t, MINOR, WorstState(MINOR, MINOR))
 }
+
+func Test_ParseStatusStrict(t *testing.T) {
+	s, err := ParseStatusStrict("minor")
+	require.NoError(t, err)
+	require.Equal(t, MINOR, s)
+
+	_, err = ParseStatusStrict("garbage")
+	require.True(t, errors.Is(err, ErrUnknownStatus))
+	require.EqualError(t, err, `unknown status "garbage"`)
+}
+
+func Test_Status_JSON(t *testing.T) {
+	type config struct {
+		Status Status `json:"status"`
+	}
+
+	b, err := json.Marshal(config{Status: MAJOR})
+	require.NoError(t, err)
+	require.Equal(t, `{"status":"MAJOR"}`, string(b))
+
+	var c config
+	require.NoError(t, json.Unmarshal([]byte(`{"status":"ok"}`), &c))
+	require.Equal(t, OK, c.Status)
+
+	require.Error(t, json.Unmarshal([]byte(`{"status":"garbage"}`), &c))
+
+	require.NoError(t, json.Unmarshal([]byte(`{"status":2}`), &c))
+	require.Equal(t, MINOR, c.Status)
+	require.Error(t, json.Unmarshal([]byte(`{"status":4}`), &c))
+
+	_, err = json.Marshal(config{Status: Status(7)})
+	require.Error(t, err)
+}
+
+func Test_Status_flag(t *testing.T) {
+	status := OK
+	flags := flag.NewFlagSet("test", flag.ContinueOnError)
+	flags.SetOutput(ioutil.Discard)
+	flags.Var(&status, "status", "the status")
+
+	require.NoError(t, flags.Parse([]string{"-status", "minor"}))
+	require.Equal(t, MINOR, status)
+	require.Error(t, flags.Parse([]string{"-status", "garbage"}))
+}
@@ -0,0 +1,43 @@
+package libhealth
+
+import (
+	"net/http"
+)
+
+// A StatusCodePolicy maps the Overall Status of a Summary to the HTTP
+// status code of a healthcheck response. While draining, the response
+// is always HTTP 503 service unavailable, regardless of the policy.
+type StatusCodePolicy func(overall Status) int
+
+// Presets of StatusCodePolicy.
+var (
+	// InfoStatusCodes is the default StatusCodePolicy of the Info
+	// handler, which fails only on OUTAGE.
+	InfoStatusCodes = FailAt(OUTAGE, http.StatusInternalServerError)
+
+	// PrivateStatusCodes is the default StatusCodePolicy of the Private
+	// handler, which fails on anything but OK.
+	PrivateStatusCodes = FailAt(MINOR, http.StatusInternalServerError)
+)
+
+// FailAt creates a StatusCodePolicy which responds with code when the
+// Overall Status is status or worse, and HTTP 200 ok otherwise. For example,
+//
+//	FailAt(MAJOR, http.StatusServiceUnavailable)
+//
+// tolerates MINOR, and responds with HTTP 503 to proxies otherwise.
+func FailAt(status Status, code int) StatusCodePolicy {
+	return func(overall Status) int {
+		if overall.SameOrWorseThan(status) {
+			return code
+		}
+		return http.StatusOK
+	}
+}
+
+func (p StatusCodePolicy) code(s Summary) int {
+	if s.draining {
+		return http.StatusServiceUnavailable
+	}
+	return p(s.Overall())
+}
@@ -0,0 +1,62 @@
+package libhealth
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestStatusCodePolicy_presets(t *testing.T) {
+	tests := []struct {
+		status  Status
+		info    int
+		private int
+	}{
+		{status: OK, info: http.StatusOK, private: http.StatusOK},
+		{status: MINOR, info: http.StatusOK, private: http.StatusInternalServerError},
+		{status: MAJOR, info: http.StatusOK, private: http.StatusInternalServerError},
+		{status: OUTAGE, info: http.StatusInternalServerError, private: http.StatusInternalServerError},
+	}
+
+	for _, test := range tests {
+		require.Equal(t, test.info, InfoStatusCodes(test.status), "info %s", test.status)
+		require.Equal(t, test.private, PrivateStatusCodes(test.status), "private %s", test.status)
+	}
+}
+
+func TestFailAt(t *testing.T) {
+	policy := FailAt(MAJOR, http.StatusServiceUnavailable)
+	require.Equal(t, http.StatusOK, policy(OK))
+	require.Equal(t, http.StatusOK, policy(MINOR))
+	require.Equal(t, http.StatusServiceUnavailable, policy(MAJOR))
+	require.Equal(t, http.StatusServiceUnavailable, policy(OUTAGE))
+}
+
+func TestWithStatusCodePolicy(t *testing.T) {
+	deps := NewBasicDependencySet(NewMonitor("weak", "", "", WEAK, func(ctx context.Context) Health {
+		return NewHealth(OUTAGE, "down")
+	}, nil))
+	defer deps.Close()
+	deps.waitUntilInitialRun()
+
+	_, code := NewPrivate("test", deps).generate(false, "host")
+	require.Equal(t, http.StatusInternalServerError, code)
+
+	tolerant := NewPrivate("test", deps, WithStatusCodePolicy(FailAt(MAJOR, http.StatusServiceUnavailable)))
+	_, code = tolerant.generate(false, "host")
+	require.Equal(t, http.StatusOK, code, "WEAK is downgraded to MINOR")
+
+	strict := NewInfo(deps, WithStatusCodePolicy(FailAt(MINOR, http.StatusServiceUnavailable)))
+	_, code = strict.generate(false, "host")
+	require.Equal(t, http.StatusServiceUnavailable, code)
+
+	_, code = NewTrigger(deps, WithStatusCodePolicy(FailAt(MAJOR, http.StatusServiceUnavailable))).
+		generate(context.Background(), "weak")
+	require.Equal(t, http.StatusOK, code)
+
+	deps.Drain()
+	_, code = tolerant.generate(false, "host")
+	require.Equal(t, http.StatusServiceUnavailable, code, "draining is always unavailable")
+}
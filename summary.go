@@ -1,7 +1,6 @@
 package libhealth
 
 import (
-	"net/http"
 	"time"
 
 	"github.com/emirpasic/gods/sets/hashset"
@@ -13,13 +12,16 @@ import (
 type Summary struct {
 	executed time.Time
 	results  []Result
+	draining bool
+	policy   AggregationPolicy // HCv3 if nil
 }
 
 // NewSummary will create a new Summary for a list of Health responses.
+// Failing results with a failing parent are marked as blocked by it.
 func NewSummary(executed time.Time, results []Result) Summary {
 	return Summary{
 		executed: executed,
-		results:  results,
+		results:  suppress(results),
 	}
 }
 
@@ -32,6 +34,30 @@ type Result struct {
 	lastGood time.Time
 	period   time.Duration
 	name     string
+	stack    string // of the panic which produced Health, if any
+	panics   int
+
+	parents   []string
+	blockedBy []string    // failing parents, from the nearest to the root cause
+	override  *overridden // which replaced Health, if any
+	tags      []string
+	initial   bool // whether the HealthMonitor has not completed a check yet
+	pending   bool // whether the Result is not counted yet, see WithGracePeriod
+}
+
+// counts returns whether r counts towards the Status of a Summary, which it
+// does unless it is blocked by a failing parent or pending.
+func (r Result) counts() bool {
+	return len(r.blockedBy) == 0 && !r.pending
+}
+
+// settled is the Status of r, or OK while r is pending, so that pending
+// Results do not cause transitions.
+func (r Result) settled() Status {
+	if r.pending {
+		return OK
+	}
+	return r.Status
 }
 
 // Executed returns the time at which s was generated by initiating
@@ -40,28 +66,41 @@ func (s Summary) Executed() time.Time {
 	return s.executed
 }
 
-// Overall will return the combined downgraded Status of all of the Health instances.
-// This state depends on both the check status and the urgency of each of the Health instances.
-func (s Summary) Overall() Status {
-	if len(s.results) == 0 {
-		return OK
+// Draining returns whether the DependencySet which produced s is draining.
+func (s Summary) Draining() bool {
+	return s.draining
+}
+
+// condition is the overall condition reported by the healthcheck endpoints.
+func (s Summary) condition() string {
+	if s.draining {
+		return Draining
 	}
+	return s.Overall().String()
+}
 
-	lowest := OK
+// Overall will return the combined downgraded Status of all of the Health instances.
+// This state depends on both the check status and the urgency of each of the Health instances,
+// as combined by the AggregationPolicy of the DependencySet, which is HCv3 by default.
+// Health instances which are blocked by a failing parent or pending are not counted.
+func (s Summary) Overall() Status {
+	counted := make([]Result, 0, len(s.results))
 	for _, d := range s.results {
-		h := d.Health
-		s := h.Status
-		downgraded := h.Urgency.DowngradeWith(lowest, s)
-		if downgraded.SameOrWorseThan(lowest) {
-			lowest = downgraded
+		if d.counts() {
+			counted = append(counted, d)
 		}
 	}
 
-	return lowest
+	if s.policy == nil {
+		return HCv3.Aggregate(counted)
+	}
+	return s.policy.Aggregate(counted)
 }
 
 // Status will return the combined downgraded Status of all of the Health instances identified by name
 // The state does NOT depend on the urgency of each of the Health instances
+// As with Overall, Health instances which are pending are not counted, nor are those blocked by a failing parent
+// which is also identified by name.
 func (s Summary) Status(names ...string) Status {
 	if len(s.results) == 0 {
 		return OK
@@ -69,7 +108,7 @@ func (s Summary) Status(names ...string) Status {
 	lowest := OK
 	set := hashset.New(variadic(names)...)
 	for _, d := range s.results {
-		if !set.Contains(d.name) {
+		if !set.Contains(d.name) || d.pending || blockedWithin(d, set) {
 			continue
 		}
 		s := d.Health.Status
@@ -82,6 +121,8 @@ func (s Summary) Status(names ...string) Status {
 
 // StatusWithUrgency will return the combined downgraded Status of all of the Health instances identified by name
 // This status depends on both the check status and the urgency of each of the Health instances.
+// As with Overall, Health instances which are pending are not counted, nor are those blocked by a failing parent
+// which is also identified by name.
 func (s Summary) StatusWithUrgency(names ...string) Status {
 	if len(s.results) == 0 {
 		return OK
@@ -89,7 +130,7 @@ func (s Summary) StatusWithUrgency(names ...string) Status {
 	lowest := OK
 	set := hashset.New(variadic(names)...)
 	for _, d := range s.results {
-		if !set.Contains(d.name) {
+		if !set.Contains(d.name) || d.pending || blockedWithin(d, set) {
 			continue
 		}
 		h := d.Health
@@ -127,17 +168,24 @@ func (s Summary) Duration() time.Duration {
 // Otherwise, any lessor status returns HTTP 200 ok.
 // The private endpoints conversely return a HTTP 200 ok if and only if the overall state is ok.
 // Any unhealthy state will return an HTTP 500 internal server error.
+// Both return HTTP 503 service unavailable while the summary is draining.
+// These are the InfoStatusCodes and PrivateStatusCodes policies.
 func ComputeStatusCode(info bool, s Summary) int {
 	if info {
-		if s.Overall() == OUTAGE {
-			return http.StatusInternalServerError
-		}
-		return http.StatusOK
+		return InfoStatusCodes.code(s)
 	}
-	if s.Overall() == OK {
-		return http.StatusOK
+	return PrivateStatusCodes.code(s)
+}
+
+// blockedWithin returns whether r is blocked by a failing parent in set, which
+// already counts the failure of r.
+func blockedWithin(r Result, set *hashset.Set) bool {
+	for _, parent := range r.blockedBy {
+		if set.Contains(parent) {
+			return true
+		}
 	}
-	return http.StatusInternalServerError
+	return false
 }
 
 func variadic(slice []string) []interface{} {
@@ -0,0 +1,59 @@
+package libhealth
+
+// suppress returns a copy of results in which every failing Result with a
+// failing parent is marked as blocked by that parent, so that a failure is
+// only counted once, for the Result at the root of it. A Result is only
+// blocked by a parent whose failure is at least as severe, once both are
+// downgraded by their Urgency, so that a failing WEAK parent does not hide
+// the outage of a REQUIRED child.
+func suppress(results []Result) []Result {
+	byName := make(map[string]*Result, len(results))
+	suppressed := make([]Result, len(results))
+	for i := range results {
+		suppressed[i] = results[i]
+		byName[results[i].name] = &suppressed[i]
+	}
+
+	for i := range suppressed {
+		suppressed[i].blockedBy = causes(byName, &suppressed[i])
+	}
+	return suppressed
+}
+
+// causes follows the failing parents of the failing result r, returning
+// their names from the nearest parent up to the root cause.
+func causes(byName map[string]*Result, r *Result) []string {
+	var chain []string
+	visited := map[string]bool{r.name: true}
+	for current := r; !current.SameAs(OK); {
+		parent := failingParent(byName, current, visited)
+		if parent == nil {
+			break
+		}
+		chain = append(chain, parent.name)
+		visited[parent.name] = true
+		current = parent
+	}
+	return chain
+}
+
+func failingParent(byName map[string]*Result, r *Result, visited map[string]bool) *Result {
+	for _, name := range r.parents {
+		parent, exists := byName[name]
+		if exists && !visited[name] && !parent.pending && !parent.SameAs(OK) && covers(parent, r) {
+			return parent
+		}
+	}
+	return nil
+}
+
+// covers returns whether the failure of parent is at least as severe as the
+// failure of child, so that counting parent alone does not lose severity.
+func covers(parent, child *Result) bool {
+	return severity(parent).SameOrWorseThan(severity(child))
+}
+
+// severity is the Status of r downgraded by its Urgency.
+func severity(r *Result) Status {
+	return r.Urgency.DowngradeWith(OK, r.Status)
+}
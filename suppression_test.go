@@ -0,0 +1,74 @@
+package libhealth
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func Test_suppress(t *testing.T) {
+	results := []Result{
+		{name: "network", Health: Health{Status: OUTAGE, Urgency: REQUIRED}},
+		{name: "cache", parents: []string{"network"}, Health: Health{Status: OUTAGE, Urgency: REQUIRED}},
+		{name: "search", parents: []string{"db", "cache"}, Health: Health{Status: OUTAGE, Urgency: STRONG}},
+		{name: "db", parents: []string{"network"}, Health: Health{Status: OK, Urgency: REQUIRED}},
+		{name: "loop", parents: []string{"loop"}, Health: Health{Status: MAJOR, Urgency: REQUIRED}},
+	}
+
+	summary := NewSummary(time.Now(), results)
+	require.Nil(t, summary.results[0].blockedBy)
+	require.Equal(t, []string{"network"}, summary.results[1].blockedBy)
+	require.Equal(t, []string{"cache", "network"}, summary.results[2].blockedBy)
+	require.Nil(t, summary.results[3].blockedBy, "OK results are not blocked")
+	require.Nil(t, summary.results[4].blockedBy)
+	require.Nil(t, results[1].blockedBy, "the original results are not modified")
+	require.Equal(t, OUTAGE, summary.Overall())
+
+	components := copyComponents(summary)
+	require.Equal(t, []string{"cache", "network"}, components[2].BlockedBy)
+	require.Contains(t, components[2].Message, "blocked by network")
+}
+
+func Test_suppress_blockedNotCounted(t *testing.T) {
+	results := []Result{
+		{name: "network", Health: Health{Status: MINOR, Urgency: WEAK}},
+		{name: "cache", parents: []string{"network"}, Health: Health{Status: OUTAGE, Urgency: WEAK}},
+		{name: "db", parents: []string{"network"}, Health: Health{Status: MINOR, Urgency: REQUIRED}},
+	}
+
+	summary := NewSummary(time.Now(), results)
+	require.Equal(t, []string{"network"}, summary.results[1].blockedBy)
+	require.Equal(t, []string{"network"}, summary.results[2].blockedBy)
+	require.Equal(t, MINOR, summary.Overall())
+}
+
+func Test_suppress_severity(t *testing.T) {
+	// a failing WEAK parent does not hide the outage of a REQUIRED child
+	results := []Result{
+		{name: "network", Health: Health{Status: OUTAGE, Urgency: WEAK}},
+		{name: "db", parents: []string{"network"}, Health: Health{Status: OUTAGE, Urgency: REQUIRED}},
+		{name: "search", parents: []string{"db"}, Health: Health{Status: OUTAGE, Urgency: REQUIRED}},
+	}
+
+	summary := NewSummary(time.Now(), results)
+	require.Nil(t, summary.results[0].blockedBy)
+	require.Nil(t, summary.results[1].blockedBy)
+	require.Equal(t, []string{"db"}, summary.results[2].blockedBy, "the chain stops at the last parent covering it")
+	require.Equal(t, OUTAGE, summary.Overall())
+	require.Equal(t, 500, ComputeStatusCode(true, summary))
+}
+
+func Test_suppress_Status(t *testing.T) {
+	summary := NewSummary(time.Now(), []Result{
+		{name: "network", Health: Health{Status: MAJOR, Urgency: REQUIRED}},
+		{name: "db", parents: []string{"network"}, Health: Health{Status: OUTAGE, Urgency: WEAK}},
+	})
+	require.Equal(t, []string{"network"}, summary.results[1].blockedBy)
+
+	// a blocked failure is reported unless its parent is also asked for
+	require.Equal(t, OUTAGE, summary.Status("db"))
+	require.Equal(t, MINOR, summary.StatusWithUrgency("db"))
+	require.Equal(t, MAJOR, summary.Status("network", "db"))
+	require.Equal(t, MAJOR, summary.StatusWithUrgency("network", "db"))
+}
@@ -0,0 +1,86 @@
+package libhealth
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+)
+
+// PrivateHealthCheckTrigger is the path prefix of the endpoint which checks a
+// single HealthMonitor, followed by its name. For example
+//
+//	/private/healthcheck/live/database
+const PrivateHealthCheckTrigger = PrivateHealthCheckLive + "/"
+
+// A Triggerer can force a single registered HealthMonitor to run its check.
+type Triggerer interface {
+	Trigger(ctx context.Context, name string) (Result, error)
+}
+
+var _ Triggerer = (*BasicDependencySet)(nil)
+
+// Trigger is an http.Handler for
+//
+//	/private/healthcheck/live/{id}
+//
+// which responds to GET and POST requests by checking the HealthMonitor
+// named id, and serving its resulting Component.
+type Trigger struct {
+	handlerConfig
+	set Triggerer
+}
+
+// NewTrigger creates a new Trigger handler for the HealthMonitors of set.
+// The status code of a response is determined by PrivateStatusCodes unless
+// configured otherwise by WithStatusCodePolicy.
+func NewTrigger(set Triggerer, options ...HandlerOption) *Trigger {
+	return &Trigger{handlerConfig: newHandlerConfig(PrivateStatusCodes, options), set: set}
+}
+
+func (t *Trigger) generate(ctx context.Context, name string) ([]byte, int) {
+	result, err := t.set.Trigger(ctx, name)
+	switch {
+	case errors.Is(err, ErrUnknownMonitor):
+		return errorJSON(err), http.StatusNotFound
+	case err != nil:
+		return errorJSON(err), http.StatusServiceUnavailable
+	}
+
+	// the Result is already blocked by its parents, which are not included
+	summary := Summary{executed: t.clock.Now(), results: []Result{result}}
+	component, err := json.Marshal(copyComponents(summary)[0])
+	if err != nil {
+		return []byte(privateBad), http.StatusInternalServerError
+	}
+	return component, t.statusCodePolicy.code(summary)
+}
+
+func (t *Trigger) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet && r.Method != http.MethodPost {
+		w.Header().Set("Allow", "GET, POST")
+		http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	name := strings.TrimPrefix(r.URL.Path, PrivateHealthCheckTrigger)
+	component, code := t.generate(r.Context(), name)
+	var prettyJSON bytes.Buffer
+	if err := json.Indent(&prettyJSON, component, "", "  "); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.WriteHeader(code)
+	if _, err := w.Write(prettyJSON.Bytes()); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+}
+
+func errorJSON(err error) []byte {
+	body, _ := json.Marshal(map[string]string{"error": err.Error()})
+	return body
+}
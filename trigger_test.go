@@ -0,0 +1,101 @@
+package libhealth
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestBasicDependencySet_Trigger(t *testing.T) {
+	var triggered, other int32
+	deps := NewBasicDependencySet(
+		countingMonitor("triggered", &triggered),
+		countingMonitor("other", &other),
+	)
+	defer deps.Close()
+	deps.waitUntilInitialRun()
+
+	result, err := deps.Trigger(context.Background(), "triggered")
+	require.NoError(t, err)
+	require.Equal(t, "triggered", result.name)
+	require.Equal(t, OK, result.Status)
+	require.Equal(t, int32(2), atomic.LoadInt32(&triggered))
+	require.Equal(t, int32(1), atomic.LoadInt32(&other))
+
+	_, err = deps.Trigger(context.Background(), "missing")
+	require.True(t, errors.Is(err, ErrUnknownMonitor))
+}
+
+func Test_Trigger_ServeHTTP(t *testing.T) {
+	var triggered int32
+	deps := NewBasicDependencySet(countingMonitor("triggered", &triggered))
+	defer deps.Close()
+	deps.waitUntilInitialRun()
+
+	mux := http.NewServeMux()
+	WrapServeMux(mux, "test_trigger", deps)
+	WrapServeMuxAdmin(mux, deps)
+
+	tests := []struct {
+		method string
+		path   string
+		code   int
+	}{
+		{method: http.MethodGet, path: "/private/healthcheck/live/triggered", code: http.StatusOK},
+		{method: http.MethodPost, path: "/private/healthcheck/live/triggered", code: http.StatusOK},
+		{method: http.MethodGet, path: "/private/healthcheck/live/missing", code: http.StatusNotFound},
+		{method: http.MethodDelete, path: "/private/healthcheck/live/triggered", code: http.StatusMethodNotAllowed},
+	}
+
+	for _, test := range tests {
+		w := httptest.NewRecorder()
+		mux.ServeHTTP(w, httptest.NewRequest(test.method, test.path, nil))
+		require.Equal(t, test.code, w.Code, "%s %s", test.method, test.path)
+
+		if test.code == http.StatusOK {
+			var component Component
+			require.NoError(t, json.Unmarshal(w.Body.Bytes(), &component))
+			require.Equal(t, "triggered", component.ID)
+			require.Equal(t, "OK", component.State)
+		}
+	}
+	require.Equal(t, int32(3), atomic.LoadInt32(&triggered))
+}
+
+func Test_WrapServeMux_withoutAdmin(t *testing.T) {
+	deps := NewBasicDependencySet()
+	defer deps.Close()
+
+	// an application may already serve the administrative paths
+	mux := http.NewServeMux()
+	mux.Handle(PrivateHealthCheckTrigger, http.NotFoundHandler())
+	require.NotPanics(t, func() {
+		WrapServeMux(mux, "test_trigger", deps)
+	})
+}
+
+func Test_Trigger_blocked(t *testing.T) {
+	deps := NewBasicDependencySet(
+		NewMonitorWithOptions("network", "", "", REQUIRED, sequenceChecker(OUTAGE, OUTAGE)),
+		NewMonitorWithOptions("db", "", "", REQUIRED, sequenceChecker(OUTAGE, OUTAGE), WithParents("network")),
+	)
+	defer deps.Close()
+	deps.waitUntilInitialRun()
+
+	result, err := deps.Trigger(context.Background(), "db")
+	require.NoError(t, err)
+	require.Equal(t, []string{"network"}, result.blockedBy)
+
+	body, code := NewTrigger(deps).generate(context.Background(), "db")
+	require.Equal(t, http.StatusOK, code, "the failure is counted for network")
+	var component Component
+	require.NoError(t, json.Unmarshal(body, &component))
+	require.Equal(t, []string{"network"}, component.BlockedBy)
+	require.Contains(t, component.Message, "blocked by network")
+}
@@ -1,10 +1,25 @@
 package libhealth
 
-import "strings"
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// ErrUnknownUrgency is returned when parsing a string which is not the
+// name of an Urgency.
+var ErrUnknownUrgency = errors.New("unknown urgency")
 
 // Urgency is a level of requirement for a service to be operational. A REQUIRED
 // service would cause major service disruption if it is not healthy. Likewise a
 // WEAK service can fail without major issues.
+//
+// An Urgency is marshalled as its name, such as "REQUIRED", by encoding/json and
+// other encodings which support encoding.TextMarshaler, and can be used as a
+// flag.Value. Its number is still accepted when decoding JSON, so that values
+// encoded before Urgency was marshalled as text can be read.
 type Urgency int
 
 const (
@@ -32,6 +47,58 @@ func ParseUrgency(urgency string) Urgency {
 	}
 }
 
+// ParseUrgencyStrict converts the given string into an Urgency, ignoring case.
+// If the string is not the name of an Urgency, an error wrapping
+// ErrUnknownUrgency is returned.
+func ParseUrgencyStrict(urgency string) (Urgency, error) {
+	u := ParseUrgency(urgency)
+	if !strings.EqualFold(u.String(), urgency) {
+		return UNKNOWN, fmt.Errorf("%w %q", ErrUnknownUrgency, urgency)
+	}
+	return u, nil
+}
+
+// MarshalText returns the name of u, or an error if u is not valid.
+func (u Urgency) MarshalText() ([]byte, error) {
+	if u < REQUIRED || u > UNKNOWN {
+		return nil, fmt.Errorf("%w %d", ErrUnknownUrgency, int(u))
+	}
+	return []byte(u.String()), nil
+}
+
+// UnmarshalText parses text as with ParseUrgencyStrict.
+func (u *Urgency) UnmarshalText(text []byte) error {
+	parsed, err := ParseUrgencyStrict(string(text))
+	if err != nil {
+		return err
+	}
+	*u = parsed
+	return nil
+}
+
+// UnmarshalJSON parses data as either the name of an Urgency, as with
+// UnmarshalText, or its number.
+func (u *Urgency) UnmarshalJSON(data []byte) error {
+	if number, err := strconv.Atoi(string(data)); err == nil {
+		if number < int(REQUIRED) || number > int(UNKNOWN) {
+			return fmt.Errorf("%w %d", ErrUnknownUrgency, number)
+		}
+		*u = Urgency(number)
+		return nil
+	}
+	var text string
+	if err := json.Unmarshal(data, &text); err != nil {
+		return err
+	}
+	return u.UnmarshalText([]byte(text))
+}
+
+// Set parses value as with ParseUrgencyStrict, so that an Urgency
+// can be used as a flag.Value.
+func (u *Urgency) Set(value string) error {
+	return u.UnmarshalText([]byte(value))
+}
+
 // String provides an obvious representation of the Urgency level
 func (u Urgency) String() string {
 	switch u {
@@ -1,6 +1,10 @@
 package libhealth
 
 import (
+	"encoding/json"
+	"errors"
+	"flag"
+	"io/ioutil"
 	"testing"
 
 	"github.com/stretchr/testify/require"
@@ -80,3 +84,51 @@ func Test_DowngradeWith_unknown(t *testing.T) {
 	require.Equal(t, MAJOR, subject.DowngradeWith(OK, MAJOR))
 	require.Equal(t, OUTAGE, subject.DowngradeWith(OK, OUTAGE))
 }
+
+func Test_ParseUrgencyStrict(t *testing.T) {
+	u, err := ParseUrgencyStrict("Strong")
+	require.NoError(t, err)
+	require.Equal(t, STRONG, u)
+
+	u, err = ParseUrgencyStrict("unknown")
+	require.NoError(t, err)
+	require.Equal(t, UNKNOWN, u)
+
+	_, err = ParseUrgencyStrict("garbage")
+	require.True(t, errors.Is(err, ErrUnknownUrgency))
+	require.EqualError(t, err, `unknown urgency "garbage"`)
+}
+
+func Test_Urgency_JSON(t *testing.T) {
+	type config struct {
+		Urgency Urgency `json:"urgency"`
+	}
+
+	b, err := json.Marshal(config{Urgency: WEAK})
+	require.NoError(t, err)
+	require.Equal(t, `{"urgency":"WEAK"}`, string(b))
+
+	var c config
+	require.NoError(t, json.Unmarshal([]byte(`{"urgency":"required"}`), &c))
+	require.Equal(t, REQUIRED, c.Urgency)
+
+	require.Error(t, json.Unmarshal([]byte(`{"urgency":"garbage"}`), &c))
+
+	require.NoError(t, json.Unmarshal([]byte(`{"urgency":2}`), &c))
+	require.Equal(t, WEAK, c.Urgency)
+	require.Error(t, json.Unmarshal([]byte(`{"urgency":-1}`), &c))
+
+	_, err = json.Marshal(config{Urgency: Urgency(-1)})
+	require.Error(t, err)
+}
+
+func Test_Urgency_flag(t *testing.T) {
+	urgency := REQUIRED
+	flags := flag.NewFlagSet("test", flag.ContinueOnError)
+	flags.SetOutput(ioutil.Discard)
+	flags.Var(&urgency, "urgency", "the urgency")
+
+	require.NoError(t, flags.Parse([]string{"-urgency", "weak"}))
+	require.Equal(t, WEAK, urgency)
+	require.Error(t, flags.Parse([]string{"-urgency", "garbage"}))
+}